@@ -0,0 +1,86 @@
+package spaniel
+
+import (
+	"container/heap"
+	"sort"
+	"time"
+)
+
+// Allocate assigns every span to a resource (e.g. a room or a vehicle) so that no resource holds
+// overlapping spans, using the minimum number of resources.
+// It returns the number of resources needed and, for every span in s, the index of its resource.
+// Contiguous spans may share a resource, as [) spans do not overlap. If several resources are free,
+// the one with the lowest index is used.
+func (s Spans) Allocate() (int, []int) {
+	order := make([]int, len(s))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return s[order[i]].Start().Before(s[order[j]].Start())
+	})
+
+	assignment := make([]int, len(s))
+	busy := &busyResources{}
+	free := &freeResources{}
+	resources := 0
+
+	for _, i := range order {
+		start := s[i].Start()
+		for busy.Len() > 0 && !(*busy)[0].end.After(start) {
+			heap.Push(free, heap.Pop(busy).(busyResource).index)
+		}
+
+		var resource int
+		if free.Len() > 0 {
+			resource = heap.Pop(free).(int)
+		} else {
+			resource = resources
+			resources++
+		}
+
+		assignment[i] = resource
+		heap.Push(busy, busyResource{end: s[i].End(), index: resource})
+	}
+
+	return resources, assignment
+}
+
+// busyResource is a resource that is occupied until end.
+type busyResource struct {
+	end   time.Time
+	index int
+}
+
+// busyResources is a heap of occupied resources, ordered by the end of their occupation.
+type busyResources []busyResource
+
+func (h busyResources) Len() int { return len(h) }
+func (h busyResources) Less(i, j int) bool {
+	if h[i].end.Equal(h[j].end) {
+		return h[i].index < h[j].index
+	}
+	return h[i].end.Before(h[j].end)
+}
+func (h busyResources) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *busyResources) Push(x interface{}) { *h = append(*h, x.(busyResource)) }
+func (h *busyResources) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// freeResources is a heap of resource indices which are available again.
+type freeResources []int
+
+func (h freeResources) Len() int            { return len(h) }
+func (h freeResources) Less(i, j int) bool  { return h[i] < h[j] }
+func (h freeResources) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *freeResources) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *freeResources) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,76 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var allocateTests = []struct {
+	description string
+	spans       Spans
+	resources   int
+	assignment  []int
+}{
+	{
+		"no spans",
+		Spans{},
+		0,
+		[]int{},
+	},
+	{
+		"back to back meetings share a room",
+		Spans{
+			New(
+				time.Date(2020, 9, 28, 10, 0, 0, 0, berlin),
+				time.Date(2020, 9, 28, 11, 0, 0, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 28, 11, 0, 0, 0, berlin),
+				time.Date(2020, 9, 28, 12, 0, 0, 0, berlin),
+			),
+		},
+		1,
+		[]int{0, 0},
+	},
+	{
+		"overlapping meetings, unsorted input",
+		Spans{
+			New(
+				time.Date(2020, 9, 28, 11, 30, 0, 0, berlin),
+				time.Date(2020, 9, 28, 13, 0, 0, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 28, 9, 0, 0, 0, berlin),
+				time.Date(2020, 9, 28, 12, 0, 0, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 28, 10, 0, 0, 0, berlin),
+				time.Date(2020, 9, 28, 11, 0, 0, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 28, 10, 30, 0, 0, berlin),
+				time.Date(2020, 9, 28, 11, 30, 0, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 28, 12, 0, 0, 0, berlin),
+				time.Date(2020, 9, 28, 14, 0, 0, 0, berlin),
+			),
+		},
+		3,
+		[]int{1, 0, 1, 2, 0},
+	},
+}
+
+func TestSpans_Allocate(t *testing.T) {
+	for _, tt := range allocateTests {
+		t.Log(tt.description)
+		resources, assignment := tt.spans.Allocate()
+		if resources != tt.resources {
+			t.Error("Expected ", tt.resources, " resources, received ", resources)
+		}
+		if !reflect.DeepEqual(assignment, tt.assignment) {
+			t.Error("Expected ", tt.assignment, "\nReceived ", assignment)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package spaniel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Row is a labelled list of spans, drawn as one line of a timeline.
+type Row struct {
+	Label string
+	Spans Spans
+}
+
+// RenderDiagram draws the spans as an ASCII timeline of width cells, as used in the comments of Without:
+// cell i covers [base+i*unit, base+(i+1)*unit) and is drawn as '+' if any span overlaps it, '-' otherwise.
+//
+//	----++++++----
+func RenderDiagram(s Spans, base time.Time, unit time.Duration, width int) string {
+	var b strings.Builder
+	for i := 0; i < width; i++ {
+		cell := New(base.Add(time.Duration(i)*unit), base.Add(time.Duration(i+1)*unit))
+		c := '-'
+		for _, span := range s {
+			if overlap(span, cell) || (IsInstant(span) && contains(cell, span.Start())) {
+				c = '+'
+				break
+			}
+		}
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
+
+// RenderDiagramRows draws every row as a line of an ASCII timeline, see RenderDiagram, followed by its label.
+//
+//	----++++++---- a
+//	------++------ b
+func RenderDiagramRows(rows []Row, base time.Time, unit time.Duration, width int) string {
+	var b strings.Builder
+	for _, row := range rows {
+		b.WriteString(RenderDiagram(row.Spans, base, unit, width))
+		if row.Label != "" {
+			b.WriteString(" " + row.Label)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// ParseDiagram turns an ASCII timeline back into spans, where every run of '+' becomes a span and
+// cell i covers [base+i*unit, base+(i+1)*unit). Leading whitespace and anything after the
+// diagram, separated by a space (e.g. a label), is ignored.
+func ParseDiagram(diagram string, base time.Time, unit time.Duration) (Spans, error) {
+	diagram = strings.TrimLeft(diagram, " \t")
+	if i := strings.IndexAny(diagram, " \t\n"); i >= 0 {
+		diagram = diagram[:i]
+	}
+
+	spans := Spans{}
+	start := -1
+	for i, c := range diagram {
+		switch c {
+		case '+':
+			if start < 0 {
+				start = i
+			}
+		case '-':
+			if start >= 0 {
+				spans = append(spans, New(base.Add(time.Duration(start)*unit), base.Add(time.Duration(i)*unit)))
+				start = -1
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", c, i)
+		}
+	}
+
+	if start >= 0 {
+		spans = append(spans, New(base.Add(time.Duration(start)*unit), base.Add(time.Duration(len(diagram))*unit)))
+	}
+
+	return spans, nil
+}
+
+// MustParseDiagram is like ParseDiagram but panics if the diagram cannot be parsed. It simplifies writing
+// spans as diagrams, e.g. in tests.
+func MustParseDiagram(diagram string, base time.Time, unit time.Duration) Spans {
+	spans, err := ParseDiagram(diagram, base, unit)
+	if err != nil {
+		panic(err)
+	}
+
+	return spans
+}
@@ -0,0 +1,72 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var diagramBase = time.Date(2020, 9, 26, 8, 0, 0, 0, berlin)
+
+func diagram(d string) Spans {
+	return MustParseDiagram(d, diagramBase, time.Hour)
+}
+
+func TestParseDiagram(t *testing.T) {
+	result, err := ParseDiagram("  -++---+ label", diagramBase, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Spans{
+		New(diagramBase.Add(time.Hour), diagramBase.Add(3*time.Hour)),
+		New(diagramBase.Add(6*time.Hour), diagramBase.Add(7*time.Hour)),
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+
+	if _, err := ParseDiagram("--x--", diagramBase, time.Hour); err == nil {
+		t.Error("Expected an error for an unknown character")
+	}
+}
+
+func TestRenderDiagramRows(t *testing.T) {
+	rows := []Row{
+		{"a", diagram("----++++++----")},
+		{"b", Spans{New(diagramBase.Add(390*time.Minute), diagramBase.Add(7*time.Hour))}},
+		{"", Spans{New(diagramBase.Add(3*time.Hour), diagramBase.Add(3*time.Hour))}},
+	}
+
+	result := RenderDiagramRows(rows, diagramBase, time.Hour, 14)
+	expected := "----++++++---- a\n" +
+		"------+------- b\n" +
+		"---+----------\n"
+	if result != expected {
+		t.Error("Expected\n", expected, "\nReceived\n", result)
+	}
+}
+
+var diagramWithoutTests = []struct {
+	description string
+	a           string
+	b           string
+	expected    string
+}{
+	{"intersector is in a", "----++++++----", "------++------", "----++--++----"},
+	{"intersector overlaps at the start", "----++++++----", "---++++-------", "-------+++----"},
+	{"intersector overlaps at the end", "----++++++----", "-------++++---", "----+++-------"},
+	{"identical spans", "----++++++----", "----++++++----", "--------------"},
+	{"intersector engulfs a", "----++++++----", "--++++++++++--", "--------------"},
+	{"no overlap", "----++++++----", "-----------++-", "----++++++----"},
+}
+
+func TestWithout_Diagrams(t *testing.T) {
+	for _, tt := range diagramWithoutTests {
+		t.Log(tt.description)
+		result := Without(diagram(tt.a)[0], diagram(tt.b)[0])
+		if rendered := RenderDiagram(result, diagramBase, time.Hour, len(tt.a)); rendered != tt.expected {
+			t.Error("Expected ", tt.expected, "\nReceived ", rendered)
+		}
+	}
+}
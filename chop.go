@@ -0,0 +1,83 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// Chop cuts every span into contiguous pieces of at most maxLen, in order. All pieces but the last
+// of a span have a length of maxLen. Unbounded spans, spans not longer than maxLen, and all spans
+// if maxLen is zero or less, are left unchanged.
+func (s Spans) Chop(maxLen time.Duration) Spans {
+	pieces := Spans{}
+	for _, a := range s {
+		if maxLen <= 0 || IsUnbounded(a) || a.End().Sub(a.Start()) <= maxLen {
+			pieces = append(pieces, a)
+			continue
+		}
+
+		for start := a.Start(); start.Before(a.End()); start = start.Add(maxLen) {
+			pieces = append(pieces, New(start, getMin(start.Add(maxLen), a.End())))
+		}
+	}
+
+	return pieces
+}
+
+// Divide cuts a span into n contiguous pieces of equal length, in order. If the length of the span
+// is not divisible by n, the remaining nanoseconds are spread over the pieces.
+// Instants, unbounded spans and an n of less than two leave the span unchanged.
+func Divide(a Span, n int) Spans {
+	if n < 2 || IsInstant(a) || IsUnbounded(a) {
+		return Spans{a}
+	}
+
+	d := int64(a.End().Sub(a.Start()))
+	cut := func(i int) time.Time {
+		return a.Start().Add(time.Duration(d/int64(n)*int64(i) + d%int64(n)*int64(i)/int64(n)))
+	}
+
+	pieces := Spans{}
+	for i := 0; i < n; i++ {
+		pieces = append(pieces, New(cut(i), cut(i+1)))
+	}
+
+	return pieces
+}
+
+// Divide cuts every span into n contiguous pieces of equal length, see Divide.
+func (s Spans) Divide(n int) Spans {
+	pieces := Spans{}
+	for _, a := range s {
+		pieces = append(pieces, Divide(a, n)...)
+	}
+
+	return pieces
+}
+
+// CutAt cuts every span at the given instants into contiguous pieces, in order.
+// Spans not containing any of the instants after their start are left unchanged.
+func (s Spans) CutAt(cuts []time.Time) Spans {
+	sorted := append([]time.Time{}, cuts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	pieces := Spans{}
+	for _, a := range s {
+		start := a.Start()
+		i := sort.Search(len(sorted), func(i int) bool { return sorted[i].After(start) })
+		if i == len(sorted) || !sorted[i].Before(a.End()) {
+			pieces = append(pieces, a)
+			continue
+		}
+
+		for ; i < len(sorted) && sorted[i].Before(a.End()); i++ {
+			if sorted[i].After(start) {
+				pieces = append(pieces, New(start, sorted[i]))
+				start = sorted[i]
+			}
+		}
+		pieces = append(pieces, New(start, a.End()))
+	}
+
+	return pieces
+}
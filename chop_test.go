@@ -0,0 +1,105 @@
+package spaniel
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var chopTests = []struct {
+	description string
+	chop        func(Spans) Spans
+	spans       Spans
+	expected    Spans
+}{
+	{
+		"chop into pieces of at most 8 hours",
+		func(s Spans) Spans { return s.Chop(8 * time.Hour) },
+		Spans{New(monday(0, 0), monday(20, 0)), New(monday(21, 0), monday(22, 0))},
+		Spans{
+			New(monday(0, 0), monday(8, 0)),
+			New(monday(8, 0), monday(16, 0)),
+			New(monday(16, 0), monday(20, 0)),
+			New(monday(21, 0), monday(22, 0)),
+		},
+	},
+	{
+		"divide into three parts",
+		func(s Spans) Spans { return s.Divide(3) },
+		Spans{New(monday(9, 0), monday(10, 0))},
+		Spans{
+			New(monday(9, 0), monday(9, 20)),
+			New(monday(9, 20), monday(9, 40)),
+			New(monday(9, 40), monday(10, 0)),
+		},
+	},
+	{
+		"cut at instants",
+		func(s Spans) Spans {
+			return s.CutAt([]time.Time{monday(12, 0), monday(9, 0), monday(10, 0), monday(9, 30)})
+		},
+		Spans{New(monday(9, 0), monday(12, 0)), New(monday(13, 0), monday(14, 0))},
+		Spans{
+			New(monday(9, 0), monday(9, 30)),
+			New(monday(9, 30), monday(10, 0)),
+			New(monday(10, 0), monday(12, 0)),
+			New(monday(13, 0), monday(14, 0)),
+		},
+	},
+}
+
+func TestSpans_Chop(t *testing.T) {
+	for _, tt := range chopTests {
+		t.Log(tt.description)
+		result := tt.chop(tt.spans)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
+
+// TestSpans_ChopProperties checks that the pieces of random spans are contiguous and exactly
+// reconstruct the original span.
+func TestSpans_ChopProperties(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	base := monday(0, 0)
+
+	for i := 0; i < 1000; i++ {
+		start := base.Add(time.Duration(r.Int63n(int64(30 * 24 * time.Hour))))
+		span := New(start, start.Add(time.Duration(r.Int63n(int64(72*time.Hour)))))
+		original := Spans{span}
+
+		var cuts []time.Time
+		for j := r.Intn(5); j > 0; j-- {
+			cuts = append(cuts, start.Add(time.Duration(r.Int63n(int64(80*time.Hour)))-4*time.Hour))
+		}
+
+		maxLen := time.Duration(r.Int63n(int64(10*time.Hour))) + 1
+		n := r.Intn(10) + 1
+
+		for _, pieces := range []Spans{
+			original.Chop(maxLen),
+			Divide(span, n),
+			original.CutAt(cuts),
+		} {
+			if !pieces[0].Start().Equal(span.Start()) || !pieces[len(pieces)-1].End().Equal(span.End()) {
+				t.Fatal("Pieces ", pieces, " do not reconstruct ", span)
+			}
+			for j := 1; j < len(pieces); j++ {
+				if !pieces[j-1].End().Equal(pieces[j].Start()) {
+					t.Fatal("Pieces ", pieces, " are not contiguous")
+				}
+			}
+			if pieces.CoveredDuration() != original.CoveredDuration() || pieces.Duration() != span.Duration() {
+				t.Fatal("Pieces ", pieces, " do not cover ", span)
+			}
+		}
+
+		for _, piece := range original.Chop(maxLen) {
+			if piece.End().Sub(piece.Start()) > maxLen {
+				t.Fatal("Piece ", piece, " is longer than ", maxLen)
+			}
+		}
+	}
+}
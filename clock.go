@@ -0,0 +1,105 @@
+package spaniel
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. It allows ongoing spans to be resolved against a fake time in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock is the Clock returning time.Now.
+var SystemClock Clock = systemClock{}
+
+// FakeClock is a Clock which only moves when told to. It is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time the clock is set to.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set sets the clock to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = t
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// OpenSpan is a span which is still ongoing, e.g. a running timer. Its end is the current time
+// of its clock, or its start if the clock is before it.
+type OpenSpan struct {
+	start time.Time
+	clock Clock
+}
+
+// NewOpen creates an ongoing span from start until now, as told by clock. A nil clock means SystemClock.
+func NewOpen(start time.Time, clock Clock) *OpenSpan {
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	return &OpenSpan{start: start, clock: clock}
+}
+
+// Start returns the start time of a span
+func (o *OpenSpan) Start() time.Time { return o.start }
+
+// End returns the current time of the span's clock
+func (o *OpenSpan) End() time.Time { return o.endAt(o.clock.Now()) }
+
+func (o *OpenSpan) endAt(at time.Time) time.Time {
+	return getMax(o.start, at)
+}
+
+// Duration returns the duration of the span up to now
+func (o *OpenSpan) Duration() time.Duration {
+	return o.End().Sub(o.start)
+}
+
+func (o *OpenSpan) String() string {
+	return fmt.Sprintf("%s - now", o.start.Format("2006-01-02 15:04"))
+}
+
+// Resolve returns a copy of the spans as concrete *TimeSpan values, which no longer change over time.
+// Ongoing spans end at at, or at their start if at is before it; all other spans keep their start and end.
+// Resolving once and then working on the result keeps all ongoing spans consistent with each other.
+func (s Spans) Resolve(at time.Time) Spans {
+	resolved := Spans{}
+	for _, span := range s {
+		end := span.End()
+		if o, ok := span.(*OpenSpan); ok {
+			end = o.endAt(at)
+		}
+		resolved = append(resolved, New(span.Start(), end))
+	}
+
+	return resolved
+}
@@ -0,0 +1,56 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOpenSpan(t *testing.T) {
+	clock := NewFakeClock(monday(10, 0))
+	timer := NewOpen(monday(9, 0), clock)
+
+	if !timer.End().Equal(monday(10, 0)) || timer.Duration() != time.Hour {
+		t.Error("Unexpected ", timer.End(), timer.Duration())
+	}
+
+	clock.Advance(30 * time.Minute)
+	if !timer.End().Equal(monday(10, 30)) {
+		t.Error("Expected the end to follow the clock, received ", timer.End())
+	}
+
+	clock.Set(monday(8, 0))
+	if !timer.End().Equal(monday(9, 0)) {
+		t.Error("Expected the end not before the start, received ", timer.End())
+	}
+}
+
+func TestOpenSpan_Intersection(t *testing.T) {
+	clock := NewFakeClock(monday(10, 0))
+	spans := Spans{NewOpen(monday(9, 0), clock), New(monday(9, 30), monday(12, 0))}
+
+	if d := spans.Intersection().Duration(); d != 30*time.Minute {
+		t.Error("Expected 30m, received ", d)
+	}
+
+	clock.Advance(time.Hour)
+	if d := spans.Intersection().Duration(); d != 90*time.Minute {
+		t.Error("Expected 90m, received ", d)
+	}
+}
+
+func TestSpans_Resolve(t *testing.T) {
+	clock := NewFakeClock(monday(10, 0))
+	spans := Spans{NewOpen(monday(9, 0), clock), New(monday(8, 0), monday(9, 30)), NewOpen(monday(12, 0), clock)}
+
+	result := spans.Resolve(monday(11, 0))
+	expected := Spans{New(monday(9, 0), monday(11, 0)), New(monday(8, 0), monday(9, 30)), New(monday(12, 0), monday(12, 0))}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+
+	clock.Advance(time.Hour)
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected resolved spans not to change with the clock")
+	}
+}
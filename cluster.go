@@ -0,0 +1,73 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// Cluster is a group of spans connected by overlaps, together with the span bounding all of them.
+type Cluster struct {
+	Spans  Spans
+	Bounds Span
+}
+
+// Clusters groups the spans into the connected components of their overlap graph, ordered by start.
+// Contiguous spans which do not overlap end up in different clusters.
+func (s Spans) Clusters() []Cluster {
+	return clusters(s, overlapping)
+}
+
+// ClustersWithTolerance groups the spans like Clusters, but also connects spans separated by a gap
+// of at most tolerance. A tolerance of zero connects contiguous spans.
+func (s Spans) ClustersWithTolerance(tolerance time.Duration) []Cluster {
+	return clusters(s, withinGap(tolerance))
+}
+
+// overlapping connects a span starting before the end of a cluster.
+func overlapping(start, end time.Time) bool {
+	return start.Before(end)
+}
+
+// withinGap connects a span starting at most tolerance after the end of a cluster.
+func withinGap(tolerance time.Duration) func(start, end time.Time) bool {
+	return func(start, end time.Time) bool {
+		return !start.After(end.Add(tolerance))
+	}
+}
+
+// clusters sorts s by start and groups the spans with clusterSorted.
+func clusters(s Spans, connected func(start, end time.Time) bool) []Cluster {
+	var sorted Spans
+	sorted = append(sorted, s...)
+	sort.Stable(ByStart(sorted))
+
+	return clusterSorted(sorted, connected)
+}
+
+// clusterSorted sweeps over spans sorted by start and adds a span to the current cluster while
+// connected reports the span's start as connected to the cluster's end.
+func clusterSorted(sorted Spans, connected func(start, end time.Time) bool) []Cluster {
+	result := []Cluster{}
+	var members Spans
+	var start, end time.Time
+
+	for _, span := range sorted {
+		if members != nil && connected(span.Start(), end) {
+			members = append(members, span)
+			end = getMax(end, span.End())
+			continue
+		}
+
+		if members != nil {
+			result = append(result, Cluster{Spans: members, Bounds: New(start, end)})
+		}
+		members = Spans{span}
+		start, end = span.Start(), span.End()
+	}
+
+	if members != nil {
+		result = append(result, Cluster{Spans: members, Bounds: New(start, end)})
+	}
+
+	return result
+}
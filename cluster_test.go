@@ -0,0 +1,70 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	alertA = New(time.Date(2020, 9, 26, 10, 0, 0, 0, berlin), time.Date(2020, 9, 26, 10, 30, 0, 0, berlin))
+	alertB = New(time.Date(2020, 9, 26, 10, 15, 0, 0, berlin), time.Date(2020, 9, 26, 11, 0, 0, 0, berlin))
+	alertC = New(time.Date(2020, 9, 26, 11, 0, 0, 0, berlin), time.Date(2020, 9, 26, 11, 20, 0, 0, berlin))
+	alertD = New(time.Date(2020, 9, 26, 11, 25, 0, 0, berlin), time.Date(2020, 9, 26, 12, 0, 0, 0, berlin))
+)
+
+var clusterTests = []struct {
+	description string
+	spans       Spans
+	tolerance   time.Duration
+	expected    []Cluster
+}{
+	{
+		"no spans",
+		Spans{},
+		-1,
+		[]Cluster{},
+	},
+	{
+		"overlapping spans only",
+		Spans{alertD, alertC, alertB, alertA},
+		-1,
+		[]Cluster{
+			{Spans{alertA, alertB}, New(alertA.Start(), alertB.End())},
+			{Spans{alertC}, alertC},
+			{Spans{alertD}, alertD},
+		},
+	},
+	{
+		"contiguous spans connected",
+		Spans{alertD, alertC, alertB, alertA},
+		0,
+		[]Cluster{
+			{Spans{alertA, alertB, alertC}, New(alertA.Start(), alertC.End())},
+			{Spans{alertD}, alertD},
+		},
+	},
+	{
+		"small gaps connected",
+		Spans{alertD, alertC, alertB, alertA},
+		5 * time.Minute,
+		[]Cluster{
+			{Spans{alertA, alertB, alertC, alertD}, New(alertA.Start(), alertD.End())},
+		},
+	},
+}
+
+func TestSpans_Clusters(t *testing.T) {
+	for _, tt := range clusterTests {
+		t.Log(tt.description)
+		var result []Cluster
+		if tt.tolerance < 0 {
+			result = tt.spans.Clusters()
+		} else {
+			result = tt.spans.ClustersWithTolerance(tt.tolerance)
+		}
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/InSitu-Software/spaniel/v2"
+)
+
+// readSpans reads spans in the given format. Times without offset are interpreted in loc.
+func readSpans(r io.Reader, format string, loc *time.Location) (spaniel.Spans, error) {
+	switch format {
+	case "csv":
+		return readCSV(r, loc)
+	case "json":
+		return readJSON(r)
+	case "iso":
+		return readISO(r, loc)
+	}
+
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// writeSpans writes spans in the given format, with all times in loc.
+func writeSpans(w io.Writer, spans spaniel.Spans, format string, loc *time.Location) error {
+	local := spaniel.Spans{}
+	for _, s := range spans {
+		local = append(local, spaniel.New(s.Start().In(loc), s.End().In(loc)))
+	}
+
+	switch format {
+	case "csv":
+		return writeCSV(w, local, loc)
+	case "json":
+		return writeJSON(w, local)
+	case "iso":
+		return writeISO(w, local)
+	}
+
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func readCSV(r io.Reader, loc *time.Location) (spaniel.Spans, error) {
+	spans, rowErrors, err := spaniel.ReadCSV(r, spaniel.CSVOptions{Start: "start", End: "end", Location: loc})
+	if err != nil {
+		return nil, err
+	}
+	if len(rowErrors) > 0 {
+		return nil, rowErrors[0]
+	}
+
+	return spans, nil
+}
+
+func writeCSV(w io.Writer, spans spaniel.Spans, loc *time.Location) error {
+	return spaniel.WriteCSV(w, spans, []spaniel.CSVColumn{
+		spaniel.StartColumn("start", time.RFC3339Nano, loc),
+		spaniel.EndColumn("end", time.RFC3339Nano, loc),
+	})
+}
+
+func readJSON(r io.Reader) (spaniel.Spans, error) {
+	var decoded []*spaniel.TimeSpan
+	if err := json.NewDecoder(r).Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	spans := spaniel.Spans{}
+	for i, s := range decoded {
+		if s == nil {
+			return nil, fmt.Errorf("element %d: null is not a span", i)
+		}
+		if s.Start().IsZero() || s.End().IsZero() {
+			return nil, fmt.Errorf("element %d: missing start or end", i)
+		}
+		spans = append(spans, s)
+	}
+
+	return spans, nil
+}
+
+func writeJSON(w io.Writer, spans spaniel.Spans) error {
+	b, err := json.MarshalIndent(spans, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+func readISO(r io.Reader, loc *time.Location) (spaniel.Spans, error) {
+	spans := spaniel.Spans{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		span, err := spaniel.ParseISO(text, loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		spans = append(spans, span)
+	}
+
+	return spans, scanner.Err()
+}
+
+func writeISO(w io.Writer, spans spaniel.Spans) error {
+	for _, s := range spans {
+		if _, err := fmt.Fprintln(w, spaniel.FormatISO(s)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
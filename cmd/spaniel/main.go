@@ -0,0 +1,175 @@
+// Command spaniel performs set operations on files of spans.
+//
+// Usage:
+//
+//	spaniel [flags] <operation> [file]
+//
+// Spans are read from stdin and written to stdout, so operations can be combined with pipes:
+//
+//	spaniel -tz Europe/Berlin -in csv union < shifts.csv | spaniel -tz Europe/Berlin -in csv split-by-day
+//
+// Operations:
+//
+//	union            merge overlapping and contiguous spans
+//	intersect [file] overlaps between the spans, or between the spans and those in file
+//	without <file>   remove the spans in file from the spans
+//	gaps             times between the spans
+//	split-by-day     cut the spans at midnight
+//	stats            count, total and covered duration and bounds of the spans
+//
+// Spans are read and written as CSV with a header row naming a start and an end column, as JSON array of
+// objects with start and end, or as ISO 8601 time intervals, one per line.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/InSitu-Software/spaniel/v2"
+)
+
+var errUsage = errors.New("usage: spaniel [-in csv|json|iso] [-out csv|json|iso] [-tz location] <union|intersect|without|gaps|split-by-day|stats> [file]")
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	if err == errUsage || err == flag.ErrHelp {
+		fmt.Fprintln(os.Stderr, errUsage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "spaniel:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("spaniel", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	in := flags.String("in", "iso", "input format: csv, json or iso")
+	out := flags.String("out", "", "output format: csv, json or iso (default: the input format)")
+	tz := flags.String("tz", "UTC", "location for times without offset, output and day boundaries")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		*out = *in
+	}
+
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		return errUsage
+	}
+	operation, files := flags.Arg(0), flags.Args()[1:]
+
+	spans, err := readSpans(stdin, *in, loc)
+	if err != nil {
+		return err
+	}
+
+	var result spaniel.Spans
+	switch {
+	case operation == "union" && len(files) == 0:
+		result = spans.Union()
+	case operation == "intersect" && len(files) == 0:
+		result = spans.Intersection()
+	case operation == "intersect" && len(files) == 1:
+		other, err := readFile(files[0], *in, loc)
+		if err != nil {
+			return err
+		}
+		result = spans.IntersectionBetween(other)
+	case operation == "without" && len(files) == 1:
+		other, err := readFile(files[0], *in, loc)
+		if err != nil {
+			return err
+		}
+		result = spans
+		for _, o := range other {
+			result = result.Without(o)
+		}
+	case operation == "gaps" && len(files) == 0:
+		result = spans.Gaps()
+	case operation == "split-by-day" && len(files) == 0:
+		if result, err = splitByDay(spans, loc); err != nil {
+			return err
+		}
+	case operation == "stats" && len(files) == 0:
+		return writeStats(stdout, spans, loc)
+	default:
+		return errUsage
+	}
+
+	return writeSpans(stdout, result, *out, loc)
+}
+
+// readFile reads spans from a file, in the format given by its extension or the default format.
+func readFile(name, format string, loc *time.Location) (spaniel.Spans, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch ext := strings.TrimPrefix(filepath.Ext(name), "."); ext {
+	case "csv", "json", "iso":
+		format = ext
+	}
+
+	return readSpans(f, format, loc)
+}
+
+// splitByDay cuts the spans at every midnight in loc. Unbounded spans cannot be split.
+func splitByDay(spans spaniel.Spans, loc *time.Location) (spaniel.Spans, error) {
+	merged := spans.Union()
+	if len(merged) == 0 {
+		return spaniel.Spans{}, nil
+	}
+	for _, s := range merged {
+		if spaniel.IsUnbounded(s) {
+			return nil, fmt.Errorf("cannot split unbounded span %s by day", spaniel.FormatISO(s))
+		}
+	}
+
+	first := merged[0].Start().In(loc)
+	last := merged[len(merged)-1].End()
+
+	var midnights []time.Time
+	for day := time.Date(first.Year(), first.Month(), first.Day()+1, 0, 0, 0, 0, loc); day.Before(last); day = day.AddDate(0, 0, 1) {
+		midnights = append(midnights, day)
+	}
+
+	return spans.CutAt(midnights), nil
+}
+
+func writeStats(w io.Writer, spans spaniel.Spans, loc *time.Location) error {
+	_, err := fmt.Fprintf(w, "count\t%d\nduration\t%s\ncovered\t%s\n", len(spans), formatDuration(spans.Duration()), formatDuration(spans.CoveredDuration()))
+	if err != nil || len(spans) == 0 {
+		return err
+	}
+
+	// FormatISO writes ".." for an unbounded first start or last end.
+	merged := spans.Union()
+	bounds := strings.Split(spaniel.FormatISO(spaniel.New(merged[0].Start().In(loc), merged[len(merged)-1].End().In(loc))), "/")
+	_, err = fmt.Fprintf(w, "first\t%s\nlast\t%s\n", bounds[0], bounds[1])
+
+	return err
+}
+
+func formatDuration(d time.Duration) string {
+	if d == spaniel.Infinite {
+		return "unbounded"
+	}
+
+	return d.String()
+}
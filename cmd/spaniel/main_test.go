@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var runTests = []struct {
+	name string
+	args []string
+}{
+	{"union", []string{"-tz", "Europe/Berlin", "union"}},
+	{"intersect", []string{"-tz", "Europe/Berlin", "intersect"}},
+	{"intersect-file", []string{"-tz", "Europe/Berlin", "-out", "csv", "intersect", "testdata/breaks.csv"}},
+	{"without", []string{"-tz", "Europe/Berlin", "without", "testdata/breaks.csv"}},
+	{"gaps", []string{"-tz", "Europe/Berlin", "-out", "csv", "gaps"}},
+	{"split-by-day", []string{"-tz", "Europe/Berlin", "-out", "json", "split-by-day"}},
+	{"split-by-day-utc", []string{"split-by-day"}},
+	{"stats", []string{"-tz", "Europe/Berlin", "stats"}},
+}
+
+func TestRun(t *testing.T) {
+	for _, tt := range runTests {
+		t.Log(tt.name)
+
+		in, err := os.Open(filepath.Join("testdata", "shifts.iso"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out bytes.Buffer
+		err = run(tt.args, in, &out)
+		in.Close()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		golden := filepath.Join("testdata", tt.name+".golden")
+		if *update {
+			if err := ioutil.WriteFile(golden, out.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expected, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out.Bytes(), expected) {
+			t.Error("Expected\n", string(expected), "\nReceived\n", out.String())
+		}
+	}
+}
+
+func TestRun_Pipe(t *testing.T) {
+	var union, gaps bytes.Buffer
+	in, err := os.Open(filepath.Join("testdata", "shifts.iso"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+
+	if err := run([]string{"-tz", "Europe/Berlin", "-out", "csv", "union"}, in, &union); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"-in", "csv", "-out", "iso", "-tz", "Europe/Berlin", "gaps"}, &union, &gaps); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "2020-09-27T06:00:00+02:00/2020-09-27T08:00:00+02:00\n"
+	if gaps.String() != expected {
+		t.Error("Expected ", expected, "\nReceived ", gaps.String())
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	for _, args := range [][]string{{}, {"unknown"}, {"without"}, {"union", "extra"}} {
+		if err := run(args, bytes.NewReader(nil), ioutil.Discard); err != errUsage {
+			t.Error("Expected usage error for ", args, ", received ", err)
+		}
+	}
+}
+
+func TestRun_Unbounded(t *testing.T) {
+	in := "2020-01-01T00:00:00Z/..\n"
+
+	if err := run([]string{"split-by-day"}, strings.NewReader(in), ioutil.Discard); err == nil {
+		t.Error("Expected an error splitting an unbounded span")
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"stats"}, strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+	expected := "count\t1\nduration\tunbounded\ncovered\tunbounded\nfirst\t2020-01-01T00:00:00Z\nlast\t..\n"
+	if out.String() != expected {
+		t.Error("Expected ", expected, "\nReceived ", out.String())
+	}
+}
+
+func TestRun_JSONNull(t *testing.T) {
+	in := `[{"start":"2020-01-01T00:00:00Z","end":"2020-01-02T00:00:00Z"},null]`
+	err := run([]string{"-in", "json", "union"}, strings.NewReader(in), ioutil.Discard)
+	if err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Error("Expected an error for element 1, received ", err)
+	}
+	for _, in := range []string{`[{"end":"2020-01-01T00:00:00Z"}]`, `[{"start":"2020-01-01T00:00:00Z"}]`} {
+		err := run([]string{"-in", "json", "union"}, strings.NewReader(in), ioutil.Discard)
+		if err == nil || !strings.Contains(err.Error(), "element 0") {
+			t.Error("Expected an error for element 0 of ", in, ", received ", err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// Rules reported in a Violation.
+const (
+	MaxDailyRule = "max-daily"
+	MinRestRule  = "min-rest"
+	BreaksRule   = "breaks"
+)
+
+// BreakRule requires breaks of at least Min in total in shifts with more than After of work.
+type BreakRule struct {
+	After time.Duration
+	Min   time.Duration
+}
+
+// ComplianceRules describe the limits of working time in a jurisdiction. Rules with a zero value are not checked.
+type ComplianceRules struct {
+	// ShiftGap is the longest gap within a shift. Longer gaps end a shift and are taken as rest.
+	ShiftGap time.Duration
+	// MaxDaily is the maximum time worked in a working day, i.e. the 24 hours from the first work
+	// after the previous working day. A working day can hold several shifts.
+	MaxDaily time.Duration
+	// MinRest is the minimum rest between two shifts.
+	MinRest time.Duration
+	// Breaks are checked for every shift; the applicable rule requiring the longest break is used.
+	Breaks []BreakRule
+	// MinBreakSegment is the minimum length of a gap to count as break.
+	MinBreakSegment time.Duration
+}
+
+// ArbZG are the rules of the German Arbeitszeitgesetz: at most 10 hours of work a working day, 11 hours of rest,
+// and breaks of 30 minutes after 6 hours and 45 minutes after 9 hours of work, in segments of at least
+// 15 minutes. Gaps of more than 6 hours are taken as rest.
+var ArbZG = ComplianceRules{
+	ShiftGap: 6 * time.Hour,
+	MaxDaily: 10 * time.Hour,
+	MinRest:  11 * time.Hour,
+	Breaks: []BreakRule{
+		{After: 6 * time.Hour, Min: 30 * time.Minute},
+		{After: 9 * time.Hour, Min: 45 * time.Minute},
+	},
+	MinBreakSegment: 15 * time.Minute,
+}
+
+// Violation is a breach of a rule found by ComplianceRules.Check.
+type Violation struct {
+	// Rule is one of MaxDailyRule, MinRestRule and BreaksRule.
+	Rule string
+	// Spans are the offending spans: the worked spans of the working day or shift, or the spans around a rest period.
+	Spans Spans
+	// Amount is the time worked too long, or the rest or break missing.
+	Amount time.Duration
+}
+
+// Check returns the violations of the rules by a person's worked spans, ordered by the start of their spans.
+// Overlapping worked spans are merged first. Working days and shifts with unbounded spans, e.g. a running
+// timer not yet resolved, are only checked for the rest before them.
+func (r ComplianceRules) Check(worked Spans) []Violation {
+	merged := worked.Union()
+	violations := r.checkDays(merged)
+
+	shifts := merged.ClustersWithTolerance(r.ShiftGap)
+	for i, shift := range shifts {
+		if i > 0 && r.MinRest > 0 {
+			previous := shifts[i-1].Spans[len(shifts[i-1].Spans)-1]
+			rest := shift.Bounds.Start().Sub(previous.End())
+			if rest < r.MinRest {
+				violations = append(violations, Violation{MinRestRule, Spans{previous, shift.Spans[0]}, r.MinRest - rest})
+			}
+		}
+
+		if IsUnbounded(shift.Bounds) {
+			continue
+		}
+
+		work := shift.Spans.Duration()
+		var required time.Duration
+		for _, rule := range r.Breaks {
+			if work > rule.After && rule.Min > required {
+				required = rule.Min
+			}
+		}
+
+		var breaks time.Duration
+		for _, gap := range gaps(shift.Spans) {
+			if d := gap.End().Sub(gap.Start()); d >= r.MinBreakSegment {
+				breaks += d
+			}
+		}
+		if breaks < required {
+			violations = append(violations, Violation{BreaksRule, shift.Spans, required - breaks})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Spans[0].Start().Before(violations[j].Spans[0].Start())
+	})
+
+	return violations
+}
+
+// checkDays checks the merged worked spans against MaxDaily. Spans reaching past the end of a
+// working day are cut, and their remainder starts the next one.
+func (r ComplianceRules) checkDays(merged Spans) []Violation {
+	violations := []Violation{}
+	if r.MaxDaily <= 0 {
+		return violations
+	}
+
+	var day Spans
+	var dayEnd time.Time
+	unbounded := false
+	flush := func() {
+		if work := day.Duration(); len(day) > 0 && !unbounded && work > r.MaxDaily {
+			violations = append(violations, Violation{MaxDailyRule, day, work - r.MaxDaily})
+		}
+		day, unbounded = nil, false
+	}
+
+	for _, span := range merged {
+		start := span.Start()
+		for {
+			if day == nil || !start.Before(dayEnd) {
+				flush()
+				dayEnd = start.Add(24 * time.Hour)
+			}
+			if IsUnbounded(span) {
+				day, unbounded = append(day, span), true
+				break
+			}
+			if span.End().After(dayEnd) {
+				day = append(day, New(start, dayEnd))
+				start = dayEnd
+				continue
+			}
+			if start.Equal(span.Start()) {
+				day = append(day, span)
+			} else {
+				day = append(day, New(start, span.End()))
+			}
+			break
+		}
+	}
+	flush()
+
+	return violations
+}
@@ -0,0 +1,87 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func tuesday(hour, min int) time.Time {
+	return monday(hour, min).AddDate(0, 0, 1)
+}
+
+var complianceTests = []struct {
+	description string
+	worked      Spans
+	expected    []Violation
+}{
+	{
+		"compliant day with a split shift",
+		Spans{New(monday(8, 0), monday(12, 0)), New(monday(12, 30), monday(14, 30)), New(monday(18, 0), monday(20, 0))},
+		[]Violation{},
+	},
+	{
+		"too long with too short a break",
+		Spans{New(monday(6, 0), monday(12, 0)), New(monday(12, 20), monday(17, 30))},
+		[]Violation{
+			{MaxDailyRule, Spans{New(monday(6, 0), monday(12, 0)), New(monday(12, 20), monday(17, 30))}, 70 * time.Minute},
+			{BreaksRule, Spans{New(monday(6, 0), monday(12, 0)), New(monday(12, 20), monday(17, 30))}, 25 * time.Minute},
+		},
+	},
+	{
+		"break segments too short to count",
+		Spans{New(monday(8, 0), monday(11, 0)), New(monday(11, 10), monday(14, 0)), New(monday(14, 10), monday(17, 0))},
+		[]Violation{
+			{BreaksRule, Spans{New(monday(8, 0), monday(11, 0)), New(monday(11, 10), monday(14, 0)), New(monday(14, 10), monday(17, 0))}, 30 * time.Minute},
+		},
+	},
+	{
+		"late shift followed by an early shift",
+		Spans{New(monday(14, 0), monday(18, 0)), New(monday(18, 30), monday(22, 0)), New(tuesday(6, 0), tuesday(12, 0))},
+		[]Violation{
+			{MaxDailyRule, Spans{New(monday(14, 0), monday(18, 0)), New(monday(18, 30), monday(22, 0)), New(tuesday(6, 0), tuesday(12, 0))}, 210 * time.Minute},
+			{MinRestRule, Spans{New(monday(18, 30), monday(22, 0)), New(tuesday(6, 0), tuesday(12, 0))}, 3 * time.Hour},
+		},
+	},
+	{
+		"split shifts exceeding the daily maximum",
+		Spans{New(monday(6, 0), monday(11, 30)), New(monday(17, 45), monday(23, 30))},
+		[]Violation{
+			{MaxDailyRule, Spans{New(monday(6, 0), monday(11, 30)), New(monday(17, 45), monday(23, 30))}, 75 * time.Minute},
+			{MinRestRule, Spans{New(monday(6, 0), monday(11, 30)), New(monday(17, 45), monday(23, 30))}, 285 * time.Minute},
+		},
+	},
+}
+
+func TestComplianceRules_Check(t *testing.T) {
+	for _, tt := range complianceTests {
+		t.Log(tt.description)
+		result := ArbZG.Check(tt.worked)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
+
+func TestComplianceRules_Custom(t *testing.T) {
+	rules := ComplianceRules{MaxDaily: 8 * time.Hour}
+	result := rules.Check(Spans{New(monday(8, 0), monday(12, 0)), New(monday(12, 0), monday(17, 0))})
+
+	if len(result) != 1 || result[0].Rule != MaxDailyRule || result[0].Amount != time.Hour {
+		t.Error("Expected an hour over the daily maximum, received ", result)
+	}
+}
+
+func TestComplianceRules_Unbounded(t *testing.T) {
+	result := ArbZG.Check(Spans{New(monday(14, 0), monday(18, 0)), New(monday(18, 30), monday(22, 0)), From(tuesday(6, 0))})
+	expected := []Violation{
+		{MinRestRule, Spans{New(monday(18, 30), monday(22, 0)), From(tuesday(6, 0))}, 3 * time.Hour},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+
+	if result := ArbZG.Check(Spans{Until(monday(9, 0)), From(tuesday(9, 0))}); len(result) != 0 {
+		t.Error("Expected no violations for unbounded shifts, received ", result)
+	}
+}
@@ -0,0 +1,313 @@
+package spaniel
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVSpan is a span read by ReadCSV. It keeps the columns not used for its start and end as attributes.
+type CSVSpan struct {
+	TimeSpan
+	Attributes map[string]string
+}
+
+// MarshalJSON implements json.Marshal
+// The span is encoded with its start and end and its attributes.
+func (s CSVSpan) MarshalJSON() ([]byte, error) {
+	return marshalWithFields(s.TimeSpan, struct {
+		Attributes map[string]string `json:"attributes"`
+	}{s.Attributes})
+}
+
+// UnmarshalJSON implements json.Unmarshal
+func (s *CSVSpan) UnmarshalJSON(b []byte) error {
+	if err := s.TimeSpan.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	var o struct {
+		Attributes map[string]string `json:"attributes"`
+	}
+	if err := json.Unmarshal(b, &o); err != nil {
+		return err
+	}
+	s.Attributes = o.Attributes
+
+	return nil
+}
+
+// CSVOptions configures ReadCSV. Two of Start, End and Duration must be set.
+type CSVOptions struct {
+	// Start, End and Duration are the names of the columns holding the start, the end and the
+	// duration of a span. Durations are parsed with time.ParseDuration, or as decimal hours.
+	Start    string
+	End      string
+	Duration string
+	// Layouts are tried in order to parse the start and end. Nil means RFC 3339 and
+	// ISO 8601 dates and times with or without seconds.
+	Layouts []string
+	// Location is used for times without offset. Nil means UTC.
+	Location *time.Location
+	// Comma is the field delimiter. Zero means ','.
+	Comma rune
+}
+
+// CSVError is an error in a single row of a CSV file.
+type CSVError struct {
+	// Line is the line the row starts on, the header being 1.
+	Line int
+	Err  error
+}
+
+func (e *CSVError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+var csvLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ReadCSV reads *CSVSpan values from a CSV file with a header row. Rows which cannot be parsed are
+// skipped and reported as CSVError; the returned error is only set if the file cannot be read at all.
+func ReadCSV(r io.Reader, opts CSVOptions) (Spans, []*CSVError, error) {
+	if opts.Layouts == nil {
+		opts.Layouts = csvLayouts
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	lines := &lineReader{r: bufio.NewReader(r)}
+	cr := csv.NewReader(lines)
+	cr.FieldsPerRecord = -1
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[name] = i
+	}
+
+	used := 0
+	for _, name := range []string{opts.Start, opts.End, opts.Duration} {
+		if name == "" {
+			continue
+		}
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("missing column %q", name)
+		}
+		used++
+	}
+	if used != 2 {
+		return nil, nil, fmt.Errorf("exactly two of start, end and duration columns are required")
+	}
+
+	spans := Spans{}
+	var rowErrors []*CSVError
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return spans, rowErrors, nil
+		}
+		if pe, ok := err.(*csv.ParseError); ok {
+			rowErrors = append(rowErrors, &CSVError{Line: pe.StartLine, Err: pe.Err})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		// Quoted fields can span several lines, so the record started that many lines before the last one read.
+		start := lines.line - newlines(record)
+
+		if len(record) != len(header) {
+			rowErrors = append(rowErrors, &CSVError{Line: start, Err: fmt.Errorf("expected %d fields, got %d", len(header), len(record))})
+			continue
+		}
+
+		span, err := opts.parse(header, columns, record)
+		if err != nil {
+			rowErrors = append(rowErrors, &CSVError{Line: start, Err: err})
+			continue
+		}
+		spans = append(spans, span)
+	}
+}
+
+// lineReader counts the lines read from r. It returns at most one line per Read, so the buffer of a
+// csv.Reader does not read ahead of the record returned last, and blank lines skipped are counted.
+type lineReader struct {
+	r      *bufio.Reader
+	line   int
+	inLine bool
+}
+
+func (l *lineReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		b, err := l.r.ReadByte()
+		if err != nil {
+			return n, err
+		}
+		if !l.inLine {
+			l.line++
+			l.inLine = true
+		}
+		p[n] = b
+		n++
+		if b == '\n' {
+			l.inLine = false
+			break
+		}
+	}
+
+	return n, nil
+}
+
+// newlines counts the newlines within the fields of a record.
+func newlines(record []string) int {
+	n := 0
+	for _, field := range record {
+		n += strings.Count(field, "\n")
+	}
+
+	return n
+}
+
+// parse builds a CSVSpan from a record.
+func (opts CSVOptions) parse(header []string, columns map[string]int, record []string) (*CSVSpan, error) {
+	var start, end time.Time
+	var err error
+
+	if opts.Start != "" {
+		if start, err = opts.parseTime(record[columns[opts.Start]]); err != nil {
+			return nil, err
+		}
+	}
+	if opts.End != "" {
+		if end, err = opts.parseTime(record[columns[opts.End]]); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Duration != "" {
+		d, err := parseCSVDuration(record[columns[opts.Duration]])
+		if err != nil {
+			return nil, err
+		}
+		if opts.Start == "" {
+			start = end.Add(-d)
+		} else {
+			end = start.Add(d)
+		}
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end %s before start %s", end, start)
+	}
+
+	attributes := map[string]string{}
+	for i, name := range header {
+		if name != opts.Start && name != opts.End && name != opts.Duration {
+			attributes[name] = record[i]
+		}
+	}
+
+	return &CSVSpan{TimeSpan: TimeSpan{start: start, end: end}, Attributes: attributes}, nil
+}
+
+func (opts CSVOptions) parseTime(s string) (time.Time, error) {
+	for _, layout := range opts.Layouts {
+		if t, err := time.ParseInLocation(layout, s, opts.Location); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+func parseCSVDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	hours, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	return time.Duration(hours * float64(time.Hour)), nil
+}
+
+// CSVColumn is a column written by WriteCSV.
+type CSVColumn struct {
+	Name  string
+	Value func(Span) string
+}
+
+// StartColumn writes the start of a span with the given layout in loc.
+func StartColumn(name, layout string, loc *time.Location) CSVColumn {
+	return CSVColumn{name, func(s Span) string { return s.Start().In(loc).Format(layout) }}
+}
+
+// EndColumn writes the end of a span with the given layout in loc.
+func EndColumn(name, layout string, loc *time.Location) CSVColumn {
+	return CSVColumn{name, func(s Span) string { return s.End().In(loc).Format(layout) }}
+}
+
+// DurationHoursColumn writes the duration of a span in decimal hours with the given number of decimals.
+func DurationHoursColumn(name string, decimals int) CSVColumn {
+	return CSVColumn{name, func(s Span) string {
+		return strconv.FormatFloat(s.End().Sub(s.Start()).Hours(), 'f', decimals, 64)
+	}}
+}
+
+// AttributeColumn writes the attribute of a *CSVSpan, or an empty string for other spans.
+func AttributeColumn(name string) CSVColumn {
+	return CSVColumn{name, func(s Span) string {
+		if c, ok := s.(*CSVSpan); ok {
+			return c.Attributes[name]
+		}
+		return ""
+	}}
+}
+
+// WriteCSV writes the spans as CSV file with a header row and the given columns.
+func WriteCSV(w io.Writer, spans Spans, columns []CSVColumn) error {
+	cw := csv.NewWriter(w)
+
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.Name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+
+	for _, s := range spans {
+		for i, c := range columns {
+			record[i] = c.Value(s)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,153 @@
+package spaniel
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const timesheet = `employee;start;end;hours
+anna;2020-09-28 08:00;2020-09-28 12:30;
+ben;2020-09-28T09:00:00+02:00;;1.5
+carla;yesterday;2020-09-28 12:00;
+dora;2020-09-28 10:00
+emil;2020-09-28 13:00;2020-09-28 12:00;
+fritz;2020-09-28 14:00;;90m
+`
+
+func TestReadCSV(t *testing.T) {
+	spans, rowErrors, err := ReadCSV(strings.NewReader(timesheet), CSVOptions{
+		Start:    "start",
+		End:      "end",
+		Location: berlin,
+		Comma:    ';',
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Spans{
+		&CSVSpan{TimeSpan{monday(8, 0), monday(12, 30)}, map[string]string{"employee": "anna", "hours": ""}},
+	}
+	if !reflect.DeepEqual(spans, expected) {
+		t.Error("Expected ", expected, "\nReceived ", spans)
+	}
+
+	var lines []int
+	for _, e := range rowErrors {
+		lines = append(lines, e.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{3, 4, 5, 6, 7}) {
+		t.Error("Expected errors on lines 3 to 7, received ", rowErrors)
+	}
+}
+
+func TestReadCSV_Duration(t *testing.T) {
+	in := "start,duration,project\n" +
+		"2020-09-28T09:00:00+02:00,1.5,spaniel\n" +
+		"2020-09-28T14:00:00+02:00,90m,\"bare\"quote\"\n" +
+		"2020-09-28T14:00:00+02:00,90m,\"other, project\"\n"
+
+	spans, rowErrors, err := ReadCSV(strings.NewReader(in), CSVOptions{Start: "start", Duration: "duration"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Spans{
+		&CSVSpan{TimeSpan{monday(9, 0), monday(10, 30)}, map[string]string{"project": "spaniel"}},
+		&CSVSpan{TimeSpan{monday(14, 0), monday(15, 30)}, map[string]string{"project": "other, project"}},
+	}
+	if len(spans) != len(expected) {
+		t.Fatal("Expected ", expected, "\nReceived ", spans)
+	}
+	for i := range spans {
+		s, e := spans[i].(*CSVSpan), expected[i].(*CSVSpan)
+		if !s.Start().Equal(e.Start()) || !s.End().Equal(e.End()) || !reflect.DeepEqual(s.Attributes, e.Attributes) {
+			t.Error("Expected ", e, e.Attributes, "\nReceived ", s, s.Attributes)
+		}
+	}
+	if len(rowErrors) != 1 || rowErrors[0].Line != 3 {
+		t.Error("Expected an error on line 3, received ", rowErrors)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	spans := Spans{
+		&CSVSpan{TimeSpan{monday(8, 0), monday(12, 30)}, map[string]string{"employee": "anna"}},
+		New(monday(13, 0), monday(13, 20)),
+	}
+
+	var b bytes.Buffer
+	err := WriteCSV(&b, spans, []CSVColumn{
+		AttributeColumn("employee"),
+		StartColumn("start", "2006-01-02 15:04", time.UTC),
+		EndColumn("end", time.RFC3339, berlin),
+		DurationHoursColumn("hours", 2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "employee,start,end,hours\n" +
+		"anna,2020-09-28 06:00,2020-09-28T12:30:00+02:00,4.50\n" +
+		",2020-09-28 11:00,2020-09-28T13:20:00+02:00,0.33\n"
+	if b.String() != expected {
+		t.Error("Expected\n", expected, "\nReceived\n", b.String())
+	}
+}
+
+func TestReadCSV_MultilineField(t *testing.T) {
+	in := "start,end,note\n" +
+		"2020-09-28T09:00:00+02:00,2020-09-28T10:00:00+02:00,\"first\nsecond\nthird\"\n" +
+		"2020-09-28T11:00:00+02:00,never,\n" +
+		"2020-09-28T12:00:00+02:00,2020-09-28T13:00:00+02:00,\"\"broken\n" +
+		"soon,2020-09-28T13:00:00+02:00,\n"
+
+	spans, rowErrors, err := ReadCSV(strings.NewReader(in), CSVOptions{Start: "start", End: "end"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 1 || spans[0].(*CSVSpan).Attributes["note"] != "first\nsecond\nthird" {
+		t.Error("Unexpected ", spans)
+	}
+
+	var lines []int
+	for _, e := range rowErrors {
+		lines = append(lines, e.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{5, 6, 7}) {
+		t.Error("Expected errors on lines 5 to 7, received ", rowErrors)
+	}
+
+	_, rowErrors, err = ReadCSV(strings.NewReader("start,end\n\n2020-09-28T09:00:00+02:00,bad\n"), CSVOptions{Start: "start", End: "end"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rowErrors) != 1 || rowErrors[0].Line != 3 {
+		t.Error("Expected an error on line 3 after a blank line, received ", rowErrors)
+	}
+}
+
+func TestCSVSpan_JSON(t *testing.T) {
+	span := &CSVSpan{TimeSpan{monday(8, 0), monday(12, 30)}, map[string]string{"employee": "anna"}}
+	b, err := json.Marshal(span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"start":"2020-09-28T08:00:00+02:00","end":"2020-09-28T12:30:00+02:00","attributes":{"employee":"anna"}}`
+	if string(b) != expected {
+		t.Error("Expected ", expected, "\nReceived ", string(b))
+	}
+
+	var decoded CSVSpan
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Start().Equal(span.Start()) || !decoded.End().Equal(span.End()) || !reflect.DeepEqual(decoded.Attributes, span.Attributes) {
+		t.Error("Expected ", span, "\nReceived ", decoded)
+	}
+}
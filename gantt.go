@@ -0,0 +1,222 @@
+package spaniel
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// GanttOptions configures WriteGantt. The zero value draws an 800px wide chart in UTC.
+type GanttOptions struct {
+	// Location is used for the labels of the time axis. Nil means UTC.
+	Location *time.Location
+	// Width is the width of the chart in pixels, including the row labels.
+	Width int
+	// RowHeight is the height of every row in pixels.
+	RowHeight int
+	// LabelWidth is the width reserved for the row labels in pixels.
+	LabelWidth int
+	// Bounds is the time range drawn. Nil means the range from the earliest start to the latest end.
+	// It must be set to a bounded span to draw unbounded spans.
+	Bounds Span
+	// Label returns the text drawn as tooltip of a span. Nil means the span's String.
+	Label func(Span) string
+	// Category returns the category of a span, which determines its colour.
+	Category func(Span) string
+	// Colors maps categories to SVG colours. Categories without a colour get one from a default palette.
+	Colors map[string]string
+	// HighlightOverlaps marks the overlaps between the spans of a row, computed with Intersection.
+	HighlightOverlaps bool
+}
+
+var ganttPalette = []string{
+	"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f",
+	"#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac",
+}
+
+var ganttTicks = []time.Duration{
+	time.Minute, 5 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour, 7 * 24 * time.Hour,
+}
+
+// ganttMonthTicks are the ticks used for charts too long for ganttTicks, in months.
+var ganttMonthTicks = []int{1, 3, 6, 12}
+
+// Rows returns a Row per key, labelled with the key and ordered by key.
+func (k KeyedSpans) Rows() []Row {
+	rows := []Row{}
+	for _, key := range k.Keys() {
+		rows = append(rows, Row{Label: key, Spans: k[key]})
+	}
+
+	return rows
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// ganttAxis returns the ticks of the time axis within bounds, at most about 10, and the layout of their labels.
+// Ticks of a day and longer follow the calendar in loc, so they stay at midnight across DST changes.
+func ganttAxis(bounds Span, loc *time.Location) ([]time.Time, string) {
+	const day = 24 * time.Hour
+	length := bounds.End().Sub(bounds.Start())
+
+	tick, months := ganttTicks[len(ganttTicks)-1], 0
+	for _, d := range ganttTicks {
+		if length/d <= 10 {
+			tick = d
+			break
+		}
+	}
+	if length/tick > 10 {
+		months = ganttMonthTicks[len(ganttMonthTicks)-1]
+		for _, m := range ganttMonthTicks {
+			if length/(time.Duration(m)*30*day) <= 10 {
+				months = m
+				break
+			}
+		}
+	}
+
+	layout := "15:04"
+	switch {
+	case months >= 12:
+		layout = "2006"
+	case months > 0:
+		layout = "2006-01"
+	case tick >= day:
+		layout = "2006-01-02"
+	case length > day:
+		layout = "01-02 15:04"
+	}
+
+	t := snap(bounds.Start(), tick, Ceil, loc).In(loc)
+	if months > 0 {
+		start := bounds.Start().In(loc)
+		t = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, loc)
+		for t.Before(start) || (int(t.Month())-1)%months != 0 {
+			t = t.AddDate(0, 1, 0)
+		}
+	}
+
+	ticks := []time.Time{}
+	for !t.After(bounds.End()) {
+		ticks = append(ticks, t)
+		switch {
+		case months > 0:
+			t = t.AddDate(0, months, 0)
+		case tick >= day:
+			t = t.AddDate(0, 0, int(tick/day))
+		default:
+			t = t.Add(tick)
+		}
+	}
+
+	return ticks, layout
+}
+
+// WriteGantt writes the rows as a standalone SVG Gantt chart to w.
+func WriteGantt(w io.Writer, rows []Row, opts GanttOptions) error {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	if opts.Width <= 0 {
+		opts.Width = 800
+	}
+	if opts.RowHeight <= 0 {
+		opts.RowHeight = 24
+	}
+	if opts.LabelWidth <= 0 {
+		opts.LabelWidth = 120
+	}
+	if opts.Label == nil {
+		opts.Label = Span.String
+	}
+	if opts.Category == nil {
+		opts.Category = func(Span) string { return "" }
+	}
+
+	bounds := opts.Bounds
+	if bounds == nil {
+		var all Spans
+		for _, row := range rows {
+			all = append(all, row.Spans...)
+		}
+		if merged := all.Union(); len(merged) > 0 {
+			bounds = New(merged[0].Start(), merged[len(merged)-1].End())
+		}
+	}
+	if bounds == nil || !bounds.Start().Before(bounds.End()) {
+		return fmt.Errorf("cannot draw a chart without duration")
+	}
+	if IsUnbounded(bounds) {
+		return fmt.Errorf("cannot draw an unbounded chart, set bounds")
+	}
+
+	const axisHeight = 20
+	chartWidth := float64(opts.Width - opts.LabelWidth)
+	height := axisHeight + len(rows)*opts.RowHeight
+	x := func(t time.Time) float64 {
+		if t.Before(bounds.Start()) {
+			t = bounds.Start()
+		}
+		if t.After(bounds.End()) {
+			t = bounds.End()
+		}
+		return float64(opts.LabelWidth) + chartWidth*float64(t.Sub(bounds.Start()))/float64(bounds.End().Sub(bounds.Start()))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"sans-serif\" font-size=\"11\">\n", opts.Width, height)
+	fmt.Fprintf(&b, "<rect width=\"%d\" height=\"%d\" fill=\"#ffffff\"/>\n", opts.Width, height)
+
+	// time axis
+	ticks, layout := ganttAxis(bounds, opts.Location)
+	for _, t := range ticks {
+		fmt.Fprintf(&b, "<line x1=\"%.2f\" y1=\"%d\" x2=\"%.2f\" y2=\"%d\" stroke=\"#dddddd\"/>\n", x(t), axisHeight, x(t), height)
+		fmt.Fprintf(&b, "<text x=\"%.2f\" y=\"14\" text-anchor=\"middle\">%s</text>\n", x(t), escape(t.Format(layout)))
+	}
+
+	// rows
+	colors := map[string]string{}
+	for category, color := range opts.Colors {
+		colors[category] = color
+	}
+	for i, row := range rows {
+		y := axisHeight + i*opts.RowHeight
+		fmt.Fprintf(&b, "<text x=\"4\" y=\"%d\">%s</text>\n", y+opts.RowHeight*2/3, escape(row.Label))
+
+		for _, span := range row.Spans {
+			if !overlap(span, bounds) {
+				continue
+			}
+
+			category := opts.Category(span)
+			color, ok := colors[category]
+			if !ok {
+				color = ganttPalette[(len(colors)-len(opts.Colors))%len(ganttPalette)]
+				colors[category] = color
+			}
+
+			fmt.Fprintf(&b, "<rect x=\"%.2f\" y=\"%d\" width=\"%.2f\" height=\"%d\" fill=\"%s\"><title>%s</title></rect>\n",
+				x(span.Start()), y+2, x(span.End())-x(span.Start()), opts.RowHeight-4, escape(color), escape(opts.Label(span)))
+		}
+
+		if opts.HighlightOverlaps && len(row.Spans) > 1 {
+			for _, o := range row.Spans.Intersection() {
+				fmt.Fprintf(&b, "<rect x=\"%.2f\" y=\"%d\" width=\"%.2f\" height=\"%d\" fill=\"#d62728\" fill-opacity=\"0.5\"/>\n",
+					x(o.Start()), y+2, x(o.End())-x(o.Start()), opts.RowHeight-4)
+			}
+		}
+	}
+
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,117 @@
+package spaniel
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestWriteGantt(t *testing.T) {
+	shifts := KeyedSpans{
+		"press": Spans{
+			roomSpan{New(monday(8, 0), monday(12, 0)), "maintenance"},
+			roomSpan{New(monday(11, 0), monday(14, 0)), "production"},
+		},
+		"lathe & mill": Spans{
+			roomSpan{New(monday(10, 30), monday(16, 0)), "production"},
+		},
+	}
+
+	var b bytes.Buffer
+	err := WriteGantt(&b, shifts.Rows(), GanttOptions{
+		Location:          berlin,
+		Category:          func(s Span) string { return s.(roomSpan).room },
+		Colors:            map[string]string{"maintenance": "#999999"},
+		HighlightOverlaps: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "gantt.golden")
+	if *update {
+		if err := ioutil.WriteFile(golden, b.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b.Bytes(), expected) {
+		t.Error("Expected\n", string(expected), "\nReceived\n", b.String())
+	}
+}
+
+func TestWriteGantt_NoSpans(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteGantt(&b, []Row{{Label: "empty"}}, GanttOptions{}); err == nil {
+		t.Error("Expected an error for a chart without duration")
+	}
+}
+
+func TestWriteGantt_Unbounded(t *testing.T) {
+	rows := []Row{{Label: "contract", Spans: Spans{From(monday(9, 0))}}}
+
+	var b bytes.Buffer
+	if err := WriteGantt(&b, rows, GanttOptions{}); err == nil {
+		t.Error("Expected an error for an unbounded chart")
+	}
+	if err := WriteGantt(&b, rows, GanttOptions{Bounds: Until(monday(12, 0))}); err == nil {
+		t.Error("Expected an error for unbounded bounds")
+	}
+
+	b.Reset()
+	if err := WriteGantt(&b, rows, GanttOptions{Bounds: New(monday(8, 0), monday(12, 0))}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b.Bytes(), []byte("<rect")) {
+		t.Error("Expected the clipped span to be drawn")
+	}
+}
+
+var ganttAxisTests = []struct {
+	description string
+	bounds      Span
+	expected    []string
+}{
+	{
+		"weekly ticks on Mondays across the DST change",
+		New(time.Date(2020, 9, 30, 12, 0, 0, 0, berlin), time.Date(2020, 11, 15, 0, 0, 0, 0, berlin)),
+		[]string{"2020-10-05", "2020-10-12", "2020-10-19", "2020-10-26", "2020-11-02", "2020-11-09"},
+	},
+	{
+		"monthly ticks",
+		New(time.Date(2020, 1, 15, 0, 0, 0, 0, berlin), time.Date(2020, 6, 10, 0, 0, 0, 0, berlin)),
+		[]string{"2020-02", "2020-03", "2020-04", "2020-05", "2020-06"},
+	},
+	{
+		"half-yearly ticks",
+		New(time.Date(2020, 1, 15, 0, 0, 0, 0, berlin), time.Date(2023, 3, 1, 0, 0, 0, 0, berlin)),
+		[]string{"2020-07", "2021-01", "2021-07", "2022-01", "2022-07", "2023-01"},
+	},
+}
+
+func TestGanttAxis(t *testing.T) {
+	for _, tt := range ganttAxisTests {
+		t.Log(tt.description)
+		ticks, layout := ganttAxis(tt.bounds, berlin)
+		result := []string{}
+		for _, tick := range ticks {
+			if tick.Hour() != 0 || tick.Minute() != 0 {
+				t.Error("Expected a tick at midnight, received ", tick)
+			}
+			result = append(result, tick.Format(layout))
+		}
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
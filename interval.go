@@ -178,9 +178,17 @@ func (s Spans) IntersectionWithHandler(intersectHandlerFunc IntersectionHandlerF
 	sorted = append(sorted, s...)
 	sort.Stable(ByStart(sorted))
 
-	actives := Spans{sorted[0]}
+	return intersectSorted(sorted, intersectHandlerFunc)
+}
 
+// intersectSorted implements IntersectionWithHandler for spans already sorted by start.
+func intersectSorted(sorted Spans, intersectHandlerFunc IntersectionHandlerFunc) Spans {
 	intersections := Spans{}
+	if len(sorted) == 0 {
+		return intersections
+	}
+
+	actives := Spans{sorted[0]}
 
 	for _, b := range sorted[1:] {
 		// Tidy up the active span list
@@ -264,17 +272,53 @@ func (s Spans) WithoutWithHandler(b Span, handlerFunc WithoutHandlerFunc) Spans
 }
 
 //Duration sums up the duration of all given Spans
+//It returns Infinite if any of the spans is unbounded. Sums of bounded spans longer than
+//about 292 years saturate just below Infinite.
 func (s Spans) Duration() time.Duration {
 	var d time.Duration
 
 	for _, span := range s {
-		sd := span.End().Sub(span.Start())
+		if IsUnbounded(span) {
+			return Infinite
+		}
+		sd := duration(span)
+		if sd > 0 && d > maxDuration-sd {
+			d = maxDuration
+			continue
+		}
 		d += sd
 	}
 
 	return d
 }
 
+// Union returns a list of Spans where overlapping and contiguous spans are merged, ordered by start.
+func (s Spans) Union() Spans {
+	return s.Close(0)
+}
+
+// Gaps returns the spans between the merged spans, i.e. the times within the bounds of s
+// not covered by any span, ordered by start.
+func (s Spans) Gaps() Spans {
+	return gaps(s.Union())
+}
+
+// gaps returns the spans between the given merged spans, which must be ordered by start.
+func gaps(merged Spans) Spans {
+	g := Spans{}
+	for i := 1; i < len(merged); i++ {
+		g = append(g, New(merged[i-1].End(), merged[i].Start()))
+	}
+
+	return g
+}
+
+// CoveredDuration returns the duration covered by at least one span. Unlike Duration,
+// overlapping parts are only counted once.
+func (s Spans) CoveredDuration() time.Duration {
+	return s.Union().Duration()
+}
+
 func (s Spans) String() string {
 	var out string
 	for _, span := range s {
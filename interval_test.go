@@ -777,3 +777,72 @@ func TestSpans_Duration(t *testing.T) {
 		}
 	}
 }
+
+var unionTests = []struct {
+	description string
+	spans       Spans
+	union       Spans
+	gaps        Spans
+	covered     time.Duration
+}{
+	{
+		"no spans",
+		Spans{},
+		Spans{},
+		Spans{},
+		0,
+	},
+	{
+		"overlapping, contiguous and separate spans",
+		Spans{
+			New(
+				time.Date(2020, 9, 26, 14, 0, 0, 0, berlin),
+				time.Date(2020, 9, 26, 15, 0, 0, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 26, 11, 27, 0, 0, berlin),
+				time.Date(2020, 9, 26, 13, 12, 45, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 26, 12, 12, 36, 0, berlin),
+				time.Date(2020, 9, 26, 13, 30, 0, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 26, 13, 30, 0, 0, berlin),
+				time.Date(2020, 9, 26, 13, 45, 0, 0, berlin),
+			),
+		},
+		Spans{
+			New(
+				time.Date(2020, 9, 26, 11, 27, 0, 0, berlin),
+				time.Date(2020, 9, 26, 13, 45, 0, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 26, 14, 0, 0, 0, berlin),
+				time.Date(2020, 9, 26, 15, 0, 0, 0, berlin),
+			),
+		},
+		Spans{
+			New(
+				time.Date(2020, 9, 26, 13, 45, 0, 0, berlin),
+				time.Date(2020, 9, 26, 14, 0, 0, 0, berlin),
+			),
+		},
+		3*time.Hour + 18*time.Minute,
+	},
+}
+
+func TestSpans_Union(t *testing.T) {
+	for _, tt := range unionTests {
+		t.Log(tt.description)
+		if union := tt.spans.Union(); !reflect.DeepEqual(union, tt.union) {
+			t.Error("Expected ", tt.union, "\nReceived ", union)
+		}
+		if gaps := tt.spans.Gaps(); !reflect.DeepEqual(gaps, tt.gaps) {
+			t.Error("Expected ", tt.gaps, "\nReceived ", gaps)
+		}
+		if covered := tt.spans.CoveredDuration(); covered != tt.covered {
+			t.Error("Expected ", tt.covered, " Received ", covered)
+		}
+	}
+}
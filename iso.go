@@ -0,0 +1,122 @@
+package spaniel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var isoLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// FormatISO returns the span as ISO 8601 time interval of its start and end, e.g.
+// "2020-09-26T15:04:05+02:00/2020-09-26T17:06:05+02:00". An unbounded start or end is
+// written as "..", as in ISO 8601-2.
+func FormatISO(s Span) string {
+	start, end := "..", ".."
+	if !isBeginning(s.Start()) {
+		start = s.Start().Format(time.RFC3339Nano)
+	}
+	if !isForever(s.End()) {
+		end = s.End().Format(time.RFC3339Nano)
+	}
+
+	return start + "/" + end
+}
+
+// ParseISO parses an ISO 8601 time interval given as start/end, start/duration or duration/end.
+// Times without offset are interpreted in loc. Durations may consist of weeks, days, hours,
+// minutes and seconds, where days are taken as 24 hours. A start or end of ".." is unbounded.
+func ParseISO(s string, loc *time.Location) (*TimeSpan, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid ISO 8601 interval %q", s)
+	}
+
+	if parts[0] == ".." || parts[1] == ".." {
+		start, end := Beginning, Forever
+		var err error
+		if parts[0] != ".." {
+			if start, err = parseISOTime(parts[0], loc); err != nil {
+				return nil, err
+			}
+		}
+		if parts[1] != ".." {
+			if end, err = parseISOTime(parts[1], loc); err != nil {
+				return nil, err
+			}
+		}
+		return New(start, end), nil
+	}
+
+	if strings.HasPrefix(parts[0], "P") {
+		d, err := parseISODuration(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseISOTime(parts[1], loc)
+		if err != nil {
+			return nil, err
+		}
+		return New(end.Add(-d), end), nil
+	}
+
+	start, err := parseISOTime(parts[0], loc)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(parts[1], "P") {
+		d, err := parseISODuration(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return New(start, start.Add(d)), nil
+	}
+
+	end, err := parseISOTime(parts[1], loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(start, end), nil
+}
+
+func parseISOTime(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range isoLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid ISO 8601 time %q", s)
+}
+
+func parseISODuration(s string) (time.Duration, error) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(strings.Replace(m[i+1], ",", ".", 1), 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(f * float64(unit))
+	}
+
+	return d, nil
+}
@@ -0,0 +1,61 @@
+package spaniel
+
+import (
+	"testing"
+	"time"
+)
+
+var isoTests = []struct {
+	description string
+	interval    string
+	expected    *TimeSpan
+}{
+	{
+		"start and end with offset",
+		"2020-09-28T09:00:00+02:00/2020-09-28T10:30:00+02:00",
+		New(monday(9, 0), monday(10, 30)),
+	},
+	{
+		"local start and duration",
+		"2020-09-28T09:00/PT1H30M",
+		New(monday(9, 0), monday(10, 30)),
+	},
+	{
+		"duration and end",
+		"P1DT0.5S/2020-09-29T09:00:00",
+		New(monday(9, 0).Add(-500*time.Millisecond), time.Date(2020, 9, 29, 9, 0, 0, 0, berlin)),
+	},
+}
+
+func TestParseISO(t *testing.T) {
+	for _, tt := range isoTests {
+		t.Log(tt.description)
+		result, err := ParseISO(tt.interval, berlin)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !result.Start().Equal(tt.expected.Start()) || !result.End().Equal(tt.expected.End()) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+
+	for _, invalid := range []string{"2020-09-28T09:00", "P/2020-09-28", "2020-09-28/PT", "2020-09-28/P1Y"} {
+		if _, err := ParseISO(invalid, berlin); err == nil {
+			t.Error("Expected an error for ", invalid)
+		}
+	}
+}
+
+func TestFormatISO(t *testing.T) {
+	span := New(monday(9, 0), monday(10, 30))
+	result := FormatISO(span)
+	if result != "2020-09-28T09:00:00+02:00/2020-09-28T10:30:00+02:00" {
+		t.Error("Unexpected ", result)
+	}
+
+	parsed, err := ParseISO(result, time.UTC)
+	if err != nil || !parsed.Start().Equal(span.Start()) || !parsed.End().Equal(span.End()) {
+		t.Error("Expected ", span, " after round trip, received ", parsed, err)
+	}
+}
@@ -0,0 +1,108 @@
+package spaniel
+
+import "sort"
+
+// JoinMode determines which pairs are returned by Join.
+type JoinMode int
+
+const (
+	// InnerJoin returns every pair of overlapping spans.
+	InnerJoin JoinMode = iota
+	// LeftJoin returns every pair of overlapping spans, and a pair without Right for every
+	// left span which does not overlap any right span.
+	LeftJoin
+	// AntiJoin only returns a pair without Right for every left span which does not overlap any right span.
+	AntiJoin
+)
+
+// JoinPair is a result of Join. Right and Overlap are nil for left spans without a match.
+type JoinPair struct {
+	Left    Span
+	Right   Span
+	Overlap Span
+}
+
+// KeyFunc returns the key of a span, e.g. the ID of the booked object.
+type KeyFunc func(Span) string
+
+// Join returns the pairs of overlapping spans of left and right, along with the span of their overlap.
+// The pairs are ordered by the position of the left span in left, then by the start of the right span.
+func Join(left, right Spans, mode JoinMode) []JoinPair {
+	return JoinByKey(left, right, nil, mode)
+}
+
+// JoinByKey works like Join, but only pairs spans for which key returns the same key.
+// A nil key pairs all spans.
+func JoinByKey(left, right Spans, key KeyFunc, mode JoinMode) []JoinPair {
+	if key == nil {
+		key = func(Span) string { return "" }
+	}
+
+	type item struct {
+		left  bool
+		index int
+		span  Span
+		key   string
+	}
+
+	items := make([]item, 0, len(left)+len(right))
+	for i, span := range left {
+		items = append(items, item{true, i, span, key(span)})
+	}
+	for i, span := range right {
+		items = append(items, item{false, i, span, key(span)})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].span.Start().Before(items[j].span.Start())
+	})
+
+	// actives holds, per side and key, the spans which may still overlap upcoming spans.
+	actives := map[bool]map[string][]item{true: {}, false: {}}
+	matches := make([][]int, len(left))
+
+	for _, it := range items {
+		start := it.span.Start()
+		others := actives[!it.left][it.key][:0]
+		for _, other := range actives[!it.left][it.key] {
+			if !other.span.End().After(start) {
+				continue
+			}
+			others = append(others, other)
+
+			if overlap(it.span, other.span) {
+				if it.left {
+					matches[it.index] = append(matches[it.index], other.index)
+				} else {
+					matches[other.index] = append(matches[other.index], it.index)
+				}
+			}
+		}
+		actives[!it.left][it.key] = others
+		actives[it.left][it.key] = append(actives[it.left][it.key], it)
+	}
+
+	pairs := []JoinPair{}
+	for i, l := range left {
+		if len(matches[i]) == 0 {
+			if mode != InnerJoin {
+				pairs = append(pairs, JoinPair{Left: l})
+			}
+			continue
+		}
+
+		if mode == AntiJoin {
+			continue
+		}
+
+		for _, j := range matches[i] {
+			r := right[j]
+			pairs = append(pairs, JoinPair{
+				Left:    l,
+				Right:   r,
+				Overlap: New(getMax(l.Start(), r.Start()), getMin(l.End(), r.End())),
+			})
+		}
+	}
+
+	return pairs
+}
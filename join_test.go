@@ -0,0 +1,80 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+)
+
+type roomSpan struct {
+	*TimeSpan
+	room string
+}
+
+var (
+	booking1 = roomSpan{New(monday(9, 0), monday(11, 0)), "A"}
+	booking2 = roomSpan{New(monday(12, 0), monday(13, 0)), "A"}
+	booking3 = roomSpan{New(monday(9, 0), monday(10, 0)), "B"}
+	price1   = roomSpan{New(monday(8, 0), monday(10, 0)), "A"}
+	price2   = roomSpan{New(monday(10, 0), monday(12, 0)), "A"}
+	price3   = roomSpan{New(monday(11, 0), monday(12, 0)), "B"}
+)
+
+var joinTests = []struct {
+	description string
+	key         KeyFunc
+	mode        JoinMode
+	expected    []JoinPair
+}{
+	{
+		"inner join by room",
+		func(s Span) string { return s.(roomSpan).room },
+		InnerJoin,
+		[]JoinPair{
+			{booking1, price1, New(monday(9, 0), monday(10, 0))},
+			{booking1, price2, New(monday(10, 0), monday(11, 0))},
+		},
+	},
+	{
+		"left join by room",
+		func(s Span) string { return s.(roomSpan).room },
+		LeftJoin,
+		[]JoinPair{
+			{booking1, price1, New(monday(9, 0), monday(10, 0))},
+			{booking1, price2, New(monday(10, 0), monday(11, 0))},
+			{booking2, nil, nil},
+			{booking3, nil, nil},
+		},
+	},
+	{
+		"anti join by room",
+		func(s Span) string { return s.(roomSpan).room },
+		AntiJoin,
+		[]JoinPair{
+			{booking2, nil, nil},
+			{booking3, nil, nil},
+		},
+	},
+	{
+		"inner join without key",
+		nil,
+		InnerJoin,
+		[]JoinPair{
+			{booking1, price1, New(monday(9, 0), monday(10, 0))},
+			{booking1, price2, New(monday(10, 0), monday(11, 0))},
+			{booking3, price1, New(monday(9, 0), monday(10, 0))},
+		},
+	},
+}
+
+func TestJoinByKey(t *testing.T) {
+	bookings := Spans{booking1, booking2, booking3}
+	prices := Spans{price3, price2, price1}
+
+	for _, tt := range joinTests {
+		t.Log(tt.description)
+		result := JoinByKey(bookings, prices, tt.key, tt.mode)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// KeyedSpans holds a list of spans per key, e.g. per employee or per machine.
+// Its operations sort all spans in a single pass and then apply the Spans operation per key.
+type KeyedSpans map[string]Spans
+
+// Summary describes the spans of a single key.
+type Summary struct {
+	// Count is the number of spans.
+	Count int
+	// Duration is the sum of the durations of all spans, see Spans.Duration.
+	Duration time.Duration
+	// Covered is the duration covered by at least one span, see Spans.CoveredDuration.
+	Covered time.Duration
+	// Bounds is the span from the earliest start to the latest end, or nil if there are no spans.
+	Bounds Span
+}
+
+// Keys returns the keys in ascending order.
+func (k KeyedSpans) Keys() []string {
+	keys := make([]string, 0, len(k))
+	for key := range k {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sorted returns the spans of every key sorted by start, using a single sort over all spans.
+func (k KeyedSpans) sorted() KeyedSpans {
+	type keyedSpan struct {
+		key  string
+		span Span
+	}
+
+	all := []keyedSpan{}
+	for _, key := range k.Keys() {
+		for _, span := range k[key] {
+			all = append(all, keyedSpan{key, span})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].span.Start().Before(all[j].span.Start())
+	})
+
+	sorted := KeyedSpans{}
+	for key := range k {
+		sorted[key] = Spans{}
+	}
+	for _, ks := range all {
+		sorted[ks.key] = append(sorted[ks.key], ks.span)
+	}
+
+	return sorted
+}
+
+// union merges overlapping and contiguous spans of a list sorted by start.
+func union(sorted Spans) Spans {
+	merged := Spans{}
+	for _, c := range clusterSorted(sorted, withinGap(0)) {
+		merged = append(merged, merge(c, keepMerged))
+	}
+
+	return merged
+}
+
+// Union returns the union of the spans of every key, see Spans.Union.
+func (k KeyedSpans) Union() KeyedSpans {
+	result := KeyedSpans{}
+	for key, spans := range k.sorted() {
+		result[key] = union(spans)
+	}
+
+	return result
+}
+
+// Intersection returns the overlaps between the spans of every key, see Spans.Intersection.
+func (k KeyedSpans) Intersection() KeyedSpans {
+	result := KeyedSpans{}
+	for key, spans := range k.sorted() {
+		result[key] = intersectSorted(spans, func(intersectingEvent1, intersectingEvent2, intersectionSpan Span) Span {
+			return intersectionSpan
+		})
+	}
+
+	return result
+}
+
+// Without removes the given Span from the spans of every key, see Spans.Without.
+func (k KeyedSpans) Without(b Span) KeyedSpans {
+	result := KeyedSpans{}
+	for key, spans := range k {
+		result[key] = spans.Without(b)
+	}
+
+	return result
+}
+
+// Gaps returns the gaps between the spans of every key, see Spans.Gaps.
+func (k KeyedSpans) Gaps() KeyedSpans {
+	result := KeyedSpans{}
+	for key, spans := range k.sorted() {
+		result[key] = gaps(union(spans))
+	}
+
+	return result
+}
+
+// Overlapping returns the spans of key a which overlap at least one span of key b, ordered by start.
+func (k KeyedSpans) Overlapping(a, b string) Spans {
+	sorted := KeyedSpans{a: k[a], b: k[b]}.sorted()
+
+	blocks := Spans{}
+	for _, c := range clusterSorted(sorted[b], overlapping) {
+		blocks = append(blocks, c.Bounds)
+	}
+
+	result := Spans{}
+	i := 0
+	for _, span := range sorted[a] {
+		for i < len(blocks) && !blocks[i].End().After(span.Start()) {
+			i++
+		}
+		for j := i; j < len(blocks) && blocks[j].Start().Before(span.End()); j++ {
+			if overlap(span, blocks[j]) {
+				result = append(result, span)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// Summaries returns a Summary for every key.
+func (k KeyedSpans) Summaries() map[string]Summary {
+	result := map[string]Summary{}
+	for key, spans := range k.sorted() {
+		merged := union(spans)
+
+		summary := Summary{
+			Count:    len(spans),
+			Duration: spans.Duration(),
+			Covered:  merged.Duration(),
+		}
+		if len(merged) > 0 {
+			summary.Bounds = New(merged[0].Start(), merged[len(merged)-1].End())
+		}
+
+		result[key] = summary
+	}
+
+	return result
+}
@@ -0,0 +1,101 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var machines = KeyedSpans{
+	"press": Spans{
+		New(monday(12, 0), monday(13, 0)),
+		New(monday(9, 0), monday(11, 0)),
+		New(monday(8, 0), monday(10, 0)),
+	},
+	"lathe": Spans{
+		New(monday(10, 30), monday(12, 30)),
+	},
+	"idle": Spans{},
+}
+
+var keyedTests = []struct {
+	description string
+	operation   func(KeyedSpans) KeyedSpans
+	expected    KeyedSpans
+}{
+	{
+		"union",
+		KeyedSpans.Union,
+		KeyedSpans{
+			"press": Spans{New(monday(8, 0), monday(11, 0)), New(monday(12, 0), monday(13, 0))},
+			"lathe": Spans{New(monday(10, 30), monday(12, 30))},
+			"idle":  Spans{},
+		},
+	},
+	{
+		"intersection",
+		KeyedSpans.Intersection,
+		KeyedSpans{
+			"press": Spans{New(monday(9, 0), monday(10, 0))},
+			"lathe": Spans{},
+			"idle":  Spans{},
+		},
+	},
+	{
+		"gaps",
+		KeyedSpans.Gaps,
+		KeyedSpans{
+			"press": Spans{New(monday(11, 0), monday(12, 0))},
+			"lathe": Spans{},
+			"idle":  Spans{},
+		},
+	},
+	{
+		"without",
+		func(k KeyedSpans) KeyedSpans { return k.Without(New(monday(10, 0), monday(12, 0))) },
+		KeyedSpans{
+			"press": Spans{
+				New(monday(12, 0), monday(13, 0)),
+				New(monday(9, 0), monday(10, 0)),
+				New(monday(8, 0), monday(10, 0)),
+			},
+			"lathe": Spans{New(monday(12, 0), monday(12, 30))},
+			"idle":  Spans{},
+		},
+	},
+}
+
+func TestKeyedSpans(t *testing.T) {
+	for _, tt := range keyedTests {
+		t.Log(tt.description)
+		result := tt.operation(machines)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
+
+func TestKeyedSpans_Overlapping(t *testing.T) {
+	result := machines.Overlapping("press", "lathe")
+	expected := Spans{New(monday(9, 0), monday(11, 0)), New(monday(12, 0), monday(13, 0))}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+
+	result = machines.Overlapping("press", "idle")
+	if !reflect.DeepEqual(result, Spans{}) {
+		t.Error("Expected no spans, received ", result)
+	}
+}
+
+func TestKeyedSpans_Summaries(t *testing.T) {
+	result := machines.Summaries()
+	expected := map[string]Summary{
+		"press": {3, 5 * time.Hour, 4 * time.Hour, New(monday(8, 0), monday(13, 0))},
+		"lathe": {1, 2 * time.Hour, 2 * time.Hour, New(monday(10, 30), monday(12, 30))},
+		"idle":  {},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+}
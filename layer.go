@@ -0,0 +1,51 @@
+package spaniel
+
+import "sort"
+
+// LayeredSpan is a segment of the timeline returned by Layer.
+type LayeredSpan struct {
+	TimeSpan
+	// Layer is the index of the layer in effect during the segment.
+	Layer int
+	// Source is the input span the segment was cut from.
+	Source Span
+}
+
+// MarshalJSON implements json.Marshal
+// The segment is encoded with its start and end, its layer and its source.
+func (s LayeredSpan) MarshalJSON() ([]byte, error) {
+	return marshalWithFields(s.TimeSpan, struct {
+		Layer  int  `json:"layer"`
+		Source Span `json:"source"`
+	}{s.Layer, s.Source})
+}
+
+// Layer flattens the given layers into a timeline of non-overlapping *LayeredSpan segments, ordered by start.
+// Later layers have a higher priority and mask everything below them, e.g. Layer(rotation, overrides, emergencies).
+// Within a layer, earlier spans mask later ones.
+func Layer(layers ...Spans) Spans {
+	segments := Spans{}
+	masks := Spans{}
+
+	for layer := len(layers) - 1; layer >= 0; layer-- {
+		for _, source := range layers[layer] {
+			pieces := Spans{source}
+			for _, mask := range masks {
+				pieces = pieces.Without(mask)
+			}
+
+			for _, piece := range pieces {
+				segments = append(segments, &LayeredSpan{
+					TimeSpan: TimeSpan{start: piece.Start(), end: piece.End()},
+					Layer:    layer,
+					Source:   source,
+				})
+			}
+			masks = append(masks, source)
+		}
+	}
+
+	sort.Stable(ByStart(segments))
+
+	return segments
+}
@@ -0,0 +1,52 @@
+package spaniel
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLayer(t *testing.T) {
+	base := New(monday(8, 0), monday(16, 0))
+	override := New(monday(10, 0), monday(12, 0))
+	emergency := New(monday(11, 0), monday(13, 0))
+
+	result := Layer(Spans{base}, Spans{override}, Spans{emergency})
+	expected := Spans{
+		&LayeredSpan{TimeSpan{monday(8, 0), monday(10, 0)}, 0, base},
+		&LayeredSpan{TimeSpan{monday(10, 0), monday(11, 0)}, 1, override},
+		&LayeredSpan{TimeSpan{monday(11, 0), monday(13, 0)}, 2, emergency},
+		&LayeredSpan{TimeSpan{monday(13, 0), monday(16, 0)}, 0, base},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+}
+
+func TestLayer_OverlapWithinLayer(t *testing.T) {
+	first := New(monday(8, 0), monday(12, 0))
+	second := New(monday(10, 0), monday(14, 0))
+
+	result := Layer(Spans{first, second})
+	expected := Spans{
+		&LayeredSpan{TimeSpan{monday(8, 0), monday(12, 0)}, 0, first},
+		&LayeredSpan{TimeSpan{monday(12, 0), monday(14, 0)}, 0, second},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+}
+
+func TestLayer_JSON(t *testing.T) {
+	segments := Layer(Spans{New(monday(8, 0), monday(9, 0))})
+	b, err := json.Marshal(segments[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"start":"2020-09-28T08:00:00+02:00","end":"2020-09-28T09:00:00+02:00","layer":0,` +
+		`"source":{"start":"2020-09-28T08:00:00+02:00","end":"2020-09-28T09:00:00+02:00"}}`
+	if string(b) != expected {
+		t.Error("Expected ", expected, "\nReceived ", string(b))
+	}
+}
@@ -0,0 +1,86 @@
+package spaniel
+
+import "time"
+
+// TransformHandlerFunc is used by the *WithHandler transformations to allow for custom span types.
+// It is passed the original span and a span with the transformed start and end, and returns the span
+// to be used in the result.
+type TransformHandlerFunc func(original, transformed Span) Span
+
+func keepTransformed(original, transformed Span) Span {
+	return transformed
+}
+
+func keepMerged(mergeInto, mergeFrom, mergeSpan Span) Span {
+	return mergeSpan
+}
+
+// merge folds the members of a cluster into a single span, calling handlerFunc for every merge.
+// A cluster with a single member is returned unchanged.
+func merge(c Cluster, handlerFunc UnionHandlerFunc) Span {
+	merged := c.Spans[0]
+	for _, span := range c.Spans[1:] {
+		mergeSpan := New(
+			getMin(merged.Start(), span.Start()),
+			getMax(merged.End(), span.End()),
+		)
+		merged = handlerFunc(merged, span, mergeSpan)
+	}
+
+	return merged
+}
+
+// CloseWithHandler merges overlapping spans and bridges gaps of at most maxGap between them,
+// ordered by start. The provided handler function is notified of every two spans being merged.
+func (s Spans) CloseWithHandler(maxGap time.Duration, handlerFunc UnionHandlerFunc) Spans {
+	closed := Spans{}
+	for _, c := range s.ClustersWithTolerance(maxGap) {
+		closed = append(closed, merge(c, handlerFunc))
+	}
+
+	return closed
+}
+
+// Close merges overlapping spans and bridges gaps of at most maxGap between them, ordered by start.
+// Close(0) merges overlapping and contiguous spans.
+func (s Spans) Close(maxGap time.Duration) Spans {
+	return s.CloseWithHandler(maxGap, keepMerged)
+}
+
+// OpenWithHandler merges overlapping and contiguous spans and drops the merged spans shorter than
+// minDuration, so short spans touching a longer one are absorbed into it while isolated ones disappear.
+// The provided handler function is notified of every two spans being merged.
+func (s Spans) OpenWithHandler(minDuration time.Duration, handlerFunc UnionHandlerFunc) Spans {
+	return filter(s.CloseWithHandler(0, handlerFunc), func(span Span) bool {
+		return span.End().Sub(span.Start()) < minDuration
+	})
+}
+
+// Open merges overlapping and contiguous spans and drops the merged spans shorter than minDuration.
+func (s Spans) Open(minDuration time.Duration) Spans {
+	return s.OpenWithHandler(minDuration, keepMerged)
+}
+
+// DilateWithHandler grows every span by d at both its start and its end.
+func (s Spans) DilateWithHandler(d time.Duration, handlerFunc TransformHandlerFunc) Spans {
+	return s.PadWithHandler(d, d, handlerFunc)
+}
+
+// Dilate grows every span by d at both its start and its end.
+func (s Spans) Dilate(d time.Duration) Spans {
+	return s.DilateWithHandler(d, keepTransformed)
+}
+
+// ErodeWithHandler shrinks every span by d at both its start and its end.
+// Spans which shrink to nothing are dropped without calling the handler.
+func (s Spans) ErodeWithHandler(d time.Duration, handlerFunc TransformHandlerFunc) Spans {
+	return transform(s, func(span Span) (time.Time, time.Time, bool) {
+		start, end := span.Start().Add(d), span.End().Add(-d)
+		return start, end, start.Before(end)
+	}, handlerFunc)
+}
+
+// Erode shrinks every span by d at both its start and its end, dropping spans which shrink to nothing.
+func (s Spans) Erode(d time.Duration) Spans {
+	return s.ErodeWithHandler(d, keepTransformed)
+}
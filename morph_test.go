@@ -0,0 +1,74 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var morphTests = []struct {
+	description string
+	transform   func(Spans) Spans
+	spans       Spans
+	expected    Spans
+}{
+	{
+		"close merges contiguous spans",
+		func(s Spans) Spans { return s.Close(0) },
+		Spans{alertD, alertC, alertB, alertA},
+		Spans{New(alertA.Start(), alertC.End()), alertD},
+	},
+	{
+		"close bridges small gaps",
+		func(s Spans) Spans { return s.Close(5 * time.Minute) },
+		Spans{alertD, alertC, alertB, alertA},
+		Spans{New(alertA.Start(), alertD.End())},
+	},
+	{
+		"open drops short isolated spans",
+		func(s Spans) Spans { return s.Open(40 * time.Minute) },
+		Spans{alertD, alertC, alertB, alertA},
+		Spans{New(alertA.Start(), alertC.End())},
+	},
+	{
+		"dilate",
+		func(s Spans) Spans { return s.Dilate(5 * time.Minute) },
+		Spans{alertA},
+		Spans{New(
+			time.Date(2020, 9, 26, 9, 55, 0, 0, berlin),
+			time.Date(2020, 9, 26, 10, 35, 0, 0, berlin),
+		)},
+	},
+	{
+		"erode drops vanishing spans",
+		func(s Spans) Spans { return s.Erode(15 * time.Minute) },
+		Spans{alertA, alertB},
+		Spans{New(
+			time.Date(2020, 9, 26, 10, 30, 0, 0, berlin),
+			time.Date(2020, 9, 26, 10, 45, 0, 0, berlin),
+		)},
+	},
+}
+
+func TestSpans_Morph(t *testing.T) {
+	for _, tt := range morphTests {
+		t.Log(tt.description)
+		result := tt.transform(tt.spans)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
+
+func TestSpans_CloseWithHandler(t *testing.T) {
+	var merged Spans
+	Spans{alertA, alertB, alertC, alertD}.CloseWithHandler(0, func(mergeInto, mergeFrom, mergeSpan Span) Span {
+		merged = append(merged, mergeFrom)
+		return mergeSpan
+	})
+
+	expected := Spans{alertB, alertC}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Error("Expected ", expected, "\nReceived ", merged)
+	}
+}
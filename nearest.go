@@ -0,0 +1,120 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// Neighbour is the result of a nearest-neighbour query. Span is nil if there is no such span.
+type Neighbour struct {
+	Span     Span
+	Distance time.Duration
+}
+
+// Next returns the first span starting at or after t, and the time from t to its start.
+func (s Spans) Next(t time.Time) Neighbour {
+	return s.NextAll([]time.Time{t})[0]
+}
+
+// Prev returns the span ending last at or before t, and the time from its end to t.
+func (s Spans) Prev(t time.Time) Neighbour {
+	return s.PrevAll([]time.Time{t})[0]
+}
+
+// Nearest returns the span closest to t. A span containing t has a distance of zero, see At;
+// of several such spans the one reaching furthest beyond t is returned.
+// If the previous and the next span are equally far from t, the previous one is returned.
+func (s Spans) Nearest(t time.Time) Neighbour {
+	return s.NearestAll([]time.Time{t})[0]
+}
+
+// NextAll returns Next for every t in ts, in a single pass over the sorted spans and query points.
+func (s Spans) NextAll(ts []time.Time) []Neighbour {
+	next, _, _ := s.neighbours(ts)
+	return next
+}
+
+// PrevAll returns Prev for every t in ts, in a single pass over the sorted spans and query points.
+func (s Spans) PrevAll(ts []time.Time) []Neighbour {
+	_, prev, _ := s.neighbours(ts)
+	return prev
+}
+
+// NearestAll returns Nearest for every t in ts, in a single pass over the sorted spans and query points.
+func (s Spans) NearestAll(ts []time.Time) []Neighbour {
+	next, prev, containing := s.neighbours(ts)
+
+	nearest := make([]Neighbour, len(ts))
+	for i := range ts {
+		switch {
+		case containing[i].Span != nil:
+			nearest[i] = containing[i]
+		case prev[i].Span == nil:
+			nearest[i] = next[i]
+		case next[i].Span == nil || prev[i].Distance <= next[i].Distance:
+			nearest[i] = prev[i]
+		default:
+			nearest[i] = next[i]
+		}
+	}
+
+	return nearest
+}
+
+// neighbours returns, for every t in ts, the next and previous span and the span containing t
+// which reaches furthest beyond t. Of several equal next or previous spans, the one coming first in s is used.
+func (s Spans) neighbours(ts []time.Time) (next, prev, containing []Neighbour) {
+	byStart := make(Spans, len(s))
+	copy(byStart, s)
+	sort.Stable(ByStart(byStart))
+
+	byEnd := make(Spans, len(s))
+	copy(byEnd, s)
+	sort.Stable(ByEnd(byEnd))
+
+	queries := make([]int, len(ts))
+	for i := range queries {
+		queries[i] = i
+	}
+	sort.SliceStable(queries, func(i, j int) bool { return ts[queries[i]].Before(ts[queries[j]]) })
+
+	next = make([]Neighbour, len(ts))
+	prev = make([]Neighbour, len(ts))
+	containing = make([]Neighbour, len(ts))
+
+	var last, latest Span
+	nextIndex, endIndex, startIndex := 0, 0, 0
+
+	for _, q := range queries {
+		t := ts[q]
+
+		for nextIndex < len(byStart) && byStart[nextIndex].Start().Before(t) {
+			nextIndex++
+		}
+		if nextIndex < len(byStart) {
+			next[q] = Neighbour{byStart[nextIndex], byStart[nextIndex].Start().Sub(t)}
+		}
+
+		for endIndex < len(byEnd) && !byEnd[endIndex].End().After(t) {
+			if last == nil || byEnd[endIndex].End().After(last.End()) {
+				last = byEnd[endIndex]
+			}
+			endIndex++
+		}
+		if last != nil {
+			prev[q] = Neighbour{last, t.Sub(last.End())}
+		}
+
+		for startIndex < len(byStart) && !byStart[startIndex].Start().After(t) {
+			if latest == nil || byStart[startIndex].End().After(latest.End()) {
+				latest = byStart[startIndex]
+			}
+			startIndex++
+		}
+		if latest != nil && latest.End().After(t) {
+			containing[q] = Neighbour{Span: latest}
+		}
+	}
+
+	return next, prev, containing
+}
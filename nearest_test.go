@@ -0,0 +1,101 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	nearestA     = New(monday(8, 0), monday(10, 0))
+	nearestB     = New(monday(12, 0), monday(13, 0))
+	nearestC     = New(monday(12, 0), monday(15, 0))
+	nearestSpans = Spans{nearestC, nearestA, nearestB}
+)
+
+var nearestTests = []struct {
+	description string
+	at          time.Time
+	next        Neighbour
+	prev        Neighbour
+	nearest     Neighbour
+}{
+	{
+		"before all spans",
+		monday(7, 0),
+		Neighbour{nearestA, time.Hour},
+		Neighbour{},
+		Neighbour{nearestA, time.Hour},
+	},
+	{
+		"within a span",
+		monday(9, 0),
+		Neighbour{nearestC, 3 * time.Hour},
+		Neighbour{},
+		Neighbour{nearestA, 0},
+	},
+	{
+		"closer to the previous span",
+		monday(10, 30),
+		Neighbour{nearestC, 90 * time.Minute},
+		Neighbour{nearestA, 30 * time.Minute},
+		Neighbour{nearestA, 30 * time.Minute},
+	},
+	{
+		"equally far from both spans",
+		monday(11, 0),
+		Neighbour{nearestC, time.Hour},
+		Neighbour{nearestA, time.Hour},
+		Neighbour{nearestA, time.Hour},
+	},
+	{
+		"closer to the next span",
+		monday(11, 30),
+		Neighbour{nearestC, 30 * time.Minute},
+		Neighbour{nearestA, 90 * time.Minute},
+		Neighbour{nearestC, 30 * time.Minute},
+	},
+	{
+		"after one span, within another",
+		monday(14, 0),
+		Neighbour{},
+		Neighbour{nearestB, time.Hour},
+		Neighbour{nearestC, 0},
+	},
+	{
+		"after all spans",
+		monday(16, 0),
+		Neighbour{},
+		Neighbour{nearestC, time.Hour},
+		Neighbour{nearestC, time.Hour},
+	},
+}
+
+func TestSpans_Nearest(t *testing.T) {
+	for _, tt := range nearestTests {
+		t.Log(tt.description)
+		if next := nearestSpans.Next(tt.at); !reflect.DeepEqual(next, tt.next) {
+			t.Error("Expected next ", tt.next, "\nReceived ", next)
+		}
+		if prev := nearestSpans.Prev(tt.at); !reflect.DeepEqual(prev, tt.prev) {
+			t.Error("Expected previous ", tt.prev, "\nReceived ", prev)
+		}
+		if nearest := nearestSpans.Nearest(tt.at); !reflect.DeepEqual(nearest, tt.nearest) {
+			t.Error("Expected nearest ", tt.nearest, "\nReceived ", nearest)
+		}
+	}
+}
+
+func TestSpans_NearestAll(t *testing.T) {
+	var ts []time.Time
+	var expected []Neighbour
+	for i := len(nearestTests) - 1; i >= 0; i-- {
+		ts = append(ts, nearestTests[i].at)
+		expected = append(expected, nearestTests[i].nearest)
+	}
+
+	result := nearestSpans.NearestAll(ts)
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+}
@@ -0,0 +1,124 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// EventKind distinguishes start from stop events.
+type EventKind int
+
+const (
+	// StartEvent marks the start of a span.
+	StartEvent EventKind = iota
+	// StopEvent marks the end of a span.
+	StopEvent
+)
+
+// Event is a start or stop record for the span identified by Key.
+type Event struct {
+	Key  string
+	Kind EventKind
+	Time time.Time
+}
+
+// OrphanPolicy determines how Pair handles start events without a matching stop event.
+type OrphanPolicy int
+
+const (
+	// DropOrphans discards orphaned starts.
+	DropOrphans OrphanPolicy = iota
+	// CloseAtCutoff closes orphaned starts at PairOptions.Cutoff, or at the next start of the same key if
+	// that comes first. Orphans starting after the cutoff are discarded.
+	CloseAtCutoff
+	// CloseAtNextStart closes orphaned starts at the next start of the same key.
+	// Orphans without a following start are discarded.
+	CloseAtNextStart
+)
+
+// PairOptions configures Pair.
+type PairOptions struct {
+	Orphans OrphanPolicy
+	Cutoff  time.Time
+}
+
+// PairResult holds the spans built by Pair for every key, and the events which could not be paired.
+// Orphaned starts are reported regardless of whether the policy closed them.
+type PairResult struct {
+	Spans        KeyedSpans
+	OrphanStarts []Event
+	OrphanStops  []Event
+	Duplicates   []Event
+}
+
+// Pair matches start and stop events per key and builds a span for every pair. The events do not need
+// to be ordered; on equal times stops are handled before starts, so back-to-back spans pair correctly.
+// Repeated events with the same key, kind and time are reported as duplicates and otherwise ignored.
+func Pair(events []Event, opts PairOptions) PairResult {
+	sorted := append([]Event{}, events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Time.Equal(sorted[j].Time) {
+			return sorted[i].Kind == StopEvent && sorted[j].Kind == StartEvent
+		}
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	type seenEvent struct {
+		key  string
+		kind EventKind
+		time int64
+	}
+
+	result := PairResult{Spans: KeyedSpans{}}
+	seen := map[seenEvent]bool{}
+	open := map[string]Event{}
+
+	for _, e := range sorted {
+		id := seenEvent{e.Key, e.Kind, e.Time.UnixNano()}
+		if seen[id] {
+			result.Duplicates = append(result.Duplicates, e)
+			continue
+		}
+		seen[id] = true
+
+		start, isOpen := open[e.Key]
+
+		if e.Kind == StopEvent {
+			if !isOpen {
+				result.OrphanStops = append(result.OrphanStops, e)
+				continue
+			}
+			result.Spans[e.Key] = append(result.Spans[e.Key], New(start.Time, e.Time))
+			delete(open, e.Key)
+			continue
+		}
+
+		if isOpen {
+			result.OrphanStarts = append(result.OrphanStarts, start)
+			end := e.Time
+			if opts.Orphans == CloseAtCutoff {
+				end = getMin(opts.Cutoff, e.Time)
+			}
+			if opts.Orphans != DropOrphans && end.After(start.Time) {
+				result.Spans[e.Key] = append(result.Spans[e.Key], New(start.Time, end))
+			}
+		}
+		open[e.Key] = e
+	}
+
+	keys := make([]string, 0, len(open))
+	for key := range open {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		start := open[key]
+		result.OrphanStarts = append(result.OrphanStarts, start)
+		if opts.Orphans == CloseAtCutoff && opts.Cutoff.After(start.Time) {
+			result.Spans[key] = append(result.Spans[key], New(start.Time, opts.Cutoff))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,76 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func monday(hour, min int) time.Time {
+	return time.Date(2020, 9, 28, hour, min, 0, 0, berlin)
+}
+
+var pairEvents = []Event{
+	{"ben", StartEvent, monday(11, 0)},
+	{"anna", StopEvent, monday(13, 0)},
+	{"anna", StartEvent, monday(12, 0)},
+	{"anna", StopEvent, monday(12, 0)},
+	{"ben", StopEvent, monday(9, 0)},
+	{"anna", StartEvent, monday(8, 0)},
+	{"anna", StopEvent, monday(13, 0)},
+	{"ben", StartEvent, monday(10, 0)},
+}
+
+var pairTests = []struct {
+	description string
+	options     PairOptions
+	ben         Spans
+}{
+	{
+		"drop orphans",
+		PairOptions{Orphans: DropOrphans},
+		nil,
+	},
+	{
+		"close orphans at the next start",
+		PairOptions{Orphans: CloseAtNextStart},
+		Spans{New(monday(10, 0), monday(11, 0))},
+	},
+	{
+		"close orphans at the cutoff",
+		PairOptions{Orphans: CloseAtCutoff, Cutoff: monday(18, 0)},
+		Spans{New(monday(10, 0), monday(11, 0)), New(monday(11, 0), monday(18, 0))},
+	},
+	{
+		"close orphans at an early cutoff",
+		PairOptions{Orphans: CloseAtCutoff, Cutoff: monday(10, 30)},
+		Spans{New(monday(10, 0), monday(10, 30))},
+	},
+}
+
+func TestPair(t *testing.T) {
+	anna := Spans{New(monday(8, 0), monday(12, 0)), New(monday(12, 0), monday(13, 0))}
+	orphanStarts := []Event{{"ben", StartEvent, monday(10, 0)}, {"ben", StartEvent, monday(11, 0)}}
+	orphanStops := []Event{{"ben", StopEvent, monday(9, 0)}}
+	duplicates := []Event{{"anna", StopEvent, monday(13, 0)}}
+
+	for _, tt := range pairTests {
+		t.Log(tt.description)
+		result := Pair(pairEvents, tt.options)
+		if !reflect.DeepEqual(result.Spans["anna"], anna) {
+			t.Error("Expected ", anna, "\nReceived ", result.Spans["anna"])
+		}
+		if !reflect.DeepEqual(result.Spans["ben"], tt.ben) {
+			t.Error("Expected ", tt.ben, "\nReceived ", result.Spans["ben"])
+		}
+		if !reflect.DeepEqual(result.OrphanStarts, orphanStarts) {
+			t.Error("Expected orphaned starts ", orphanStarts, "\nReceived ", result.OrphanStarts)
+		}
+		if !reflect.DeepEqual(result.OrphanStops, orphanStops) {
+			t.Error("Expected orphaned stops ", orphanStops, "\nReceived ", result.OrphanStops)
+		}
+		if !reflect.DeepEqual(result.Duplicates, duplicates) {
+			t.Error("Expected duplicates ", duplicates, "\nReceived ", result.Duplicates)
+		}
+	}
+}
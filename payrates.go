@@ -0,0 +1,233 @@
+package spaniel
+
+import "time"
+
+// RateRule assigns a rate class, e.g. a night or Sunday surcharge, to parts of the worked time.
+type RateRule interface {
+	// RateClass returns the class assigned by the rule.
+	RateClass() string
+	// Windows returns the spans during which the class applies, covering at least the given days.
+	// worked are the merged worked spans, ordered by start.
+	Windows(days Spans, worked Spans, loc *time.Location) Spans
+}
+
+// HolidayCalendar tells whether a day is a public holiday.
+type HolidayCalendar interface {
+	// IsHoliday is passed the local midnight starting the day.
+	IsHoliday(day time.Time) bool
+}
+
+// HolidaySet is a HolidayCalendar of fixed dates, keyed as "2006-01-02".
+type HolidaySet map[string]bool
+
+// NewHolidaySet creates a HolidaySet of the dates of the given times.
+func NewHolidaySet(dates ...time.Time) HolidaySet {
+	set := HolidaySet{}
+	for _, d := range dates {
+		set[d.Format("2006-01-02")] = true
+	}
+
+	return set
+}
+
+// IsHoliday returns true if the date of day is in the set.
+func (h HolidaySet) IsHoliday(day time.Time) bool {
+	return h[day.Format("2006-01-02")]
+}
+
+// NightRule applies from From until To every day, both given as offset from local midnight.
+// If To is not after From, the window ends on the following day, e.g. from 23:00 until 06:00.
+type NightRule struct {
+	Class string
+	From  time.Duration
+	To    time.Duration
+}
+
+// RateClass implements RateRule
+func (r NightRule) RateClass() string { return r.Class }
+
+// Windows implements RateRule
+func (r NightRule) Windows(days Spans, worked Spans, loc *time.Location) Spans {
+	windows := Spans{}
+	if len(days) == 0 {
+		return windows
+	}
+
+	// The window of the day before the first one may reach into it.
+	first := days[0].Start().AddDate(0, 0, -1)
+	for _, day := range append(Spans{New(first, days[0].Start())}, days...) {
+		end := atOffset(day.Start(), r.To)
+		if r.To <= r.From {
+			end = atOffset(day.End(), r.To)
+		}
+		windows = append(windows, New(atOffset(day.Start(), r.From), end))
+	}
+
+	return windows
+}
+
+// WeekdayRule applies all day on the given weekdays, e.g. Sundays.
+type WeekdayRule struct {
+	Class    string
+	Weekdays []time.Weekday
+}
+
+// RateClass implements RateRule
+func (r WeekdayRule) RateClass() string { return r.Class }
+
+// Windows implements RateRule
+func (r WeekdayRule) Windows(days Spans, worked Spans, loc *time.Location) Spans {
+	return filter(days, func(day Span) bool {
+		for _, w := range r.Weekdays {
+			if day.Start().Weekday() == w {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// HolidayRule applies all day on the holidays of Calendar.
+type HolidayRule struct {
+	Class    string
+	Calendar HolidayCalendar
+}
+
+// RateClass implements RateRule
+func (r HolidayRule) RateClass() string { return r.Class }
+
+// Windows implements RateRule
+func (r HolidayRule) Windows(days Spans, worked Spans, loc *time.Location) Spans {
+	return filter(days, func(day Span) bool {
+		return !r.Calendar.IsHoliday(day.Start())
+	})
+}
+
+// OvertimeRule applies to the time worked on a day beyond After.
+type OvertimeRule struct {
+	Class string
+	After time.Duration
+}
+
+// RateClass implements RateRule
+func (r OvertimeRule) RateClass() string { return r.Class }
+
+// Windows implements RateRule
+func (r OvertimeRule) Windows(days Spans, worked Spans, loc *time.Location) Spans {
+	windows := Spans{}
+	for _, day := range days {
+		today := Spans{day}
+		total := time.Duration(0)
+		for _, w := range today.IntersectionBetween(worked) {
+			if total+w.End().Sub(w.Start()) > r.After {
+				windows = append(windows, New(getMax(w.Start(), w.Start().Add(r.After-total)), w.End()))
+			}
+			total += w.End().Sub(w.Start())
+		}
+	}
+
+	return windows
+}
+
+// RateSegment is a part of the worked time with the same rate classes.
+type RateSegment struct {
+	TimeSpan
+	// Classes are the classes of the rules applying to the segment, in the order of the rules.
+	Classes []string
+}
+
+// RateResult is returned by SegmentRates.
+type RateResult struct {
+	// Segments are the *RateSegment values covering the worked time, ordered by start.
+	Segments Spans
+	// Worked is the total worked time.
+	Worked time.Duration
+	// Totals holds the time worked in every class. Time in several classes counts for each of them.
+	Totals map[string]time.Duration
+}
+
+// SegmentRates splits the worked spans into segments tagged with the classes of the rules applying to them.
+// Classes stack, so a segment can be both at night and on a holiday. Overlapping worked spans are merged
+// first, and days are taken in loc. Rules are not applied to unbounded spans.
+func SegmentRates(worked Spans, rules []RateRule, loc *time.Location) RateResult {
+	merged := worked.Union()
+	result := RateResult{Segments: Spans{}, Totals: map[string]time.Duration{}, Worked: merged.Duration()}
+	if len(merged) == 0 {
+		return result
+	}
+
+	bounds := New(merged[0].Start(), merged[len(merged)-1].End())
+	days := Spans{}
+	if !IsUnbounded(bounds) {
+		days = localDays(bounds, loc)
+	}
+
+	windows := make([]Spans, len(rules))
+	var cuts []time.Time
+	for i, rule := range rules {
+		windows[i] = rule.Windows(days, merged, loc)
+		for _, w := range windows[i] {
+			cuts = append(cuts, w.Start(), w.End())
+		}
+	}
+
+	var last *RateSegment
+	for _, piece := range merged.CutAt(cuts) {
+		classes := []string{}
+		for i, rule := range rules {
+			// Pieces are cut at the window bounds, so any intersection covers the whole piece.
+			if len(Spans{piece}.IntersectionBetween(windows[i])) > 0 {
+				classes = append(classes, rule.RateClass())
+			}
+		}
+
+		d := piece.End().Sub(piece.Start())
+		for _, class := range classes {
+			result.Totals[class] += d
+		}
+
+		if last != nil && last.end.Equal(piece.Start()) && equalClasses(last.Classes, classes) {
+			last.end = piece.End()
+			continue
+		}
+		last = &RateSegment{TimeSpan: TimeSpan{start: piece.Start(), end: piece.End()}, Classes: classes}
+		result.Segments = append(result.Segments, last)
+	}
+
+	return result
+}
+
+// localDays returns the days in loc overlapping bounds.
+func localDays(bounds Span, loc *time.Location) Spans {
+	start := bounds.Start().In(loc)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+
+	d := Spans{}
+	for ; day.Before(bounds.End()) || len(d) == 0; day = day.AddDate(0, 0, 1) {
+		d = append(d, New(day, day.AddDate(0, 0, 1)))
+	}
+
+	return d
+}
+
+// atOffset returns the local time offset from midnight, keeping the wall clock across DST changes.
+func atOffset(midnight time.Time, offset time.Duration) time.Time {
+	return time.Date(
+		midnight.Year(), midnight.Month(), midnight.Day(),
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), int(offset%time.Minute/time.Second),
+		0, midnight.Location(),
+	)
+}
+
+func equalClasses(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
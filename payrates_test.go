@@ -0,0 +1,64 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var rateRules = []RateRule{
+	NightRule{Class: "night", From: 23 * time.Hour, To: 6 * time.Hour},
+	WeekdayRule{Class: "sunday", Weekdays: []time.Weekday{time.Sunday}},
+	HolidayRule{Class: "holiday", Calendar: NewHolidaySet(time.Date(2020, 10, 3, 0, 0, 0, 0, time.UTC))},
+	OvertimeRule{Class: "overtime", After: 8 * time.Hour},
+}
+
+func unity(day, hour int) time.Time {
+	return time.Date(2020, 10, day, hour, 0, 0, 0, berlin)
+}
+
+var rateTests = []struct {
+	description string
+	worked      Spans
+	segments    Spans
+	totals      map[string]time.Duration
+}{
+	{
+		"holiday night into sunday",
+		Spans{New(unity(3, 20), unity(4, 4))},
+		Spans{
+			&RateSegment{TimeSpan{unity(3, 20), unity(3, 23)}, []string{"holiday"}},
+			&RateSegment{TimeSpan{unity(3, 23), unity(4, 0)}, []string{"night", "holiday"}},
+			&RateSegment{TimeSpan{unity(4, 0), unity(4, 4)}, []string{"night", "sunday"}},
+		},
+		map[string]time.Duration{"night": 5 * time.Hour, "holiday": 4 * time.Hour, "sunday": 4 * time.Hour},
+	},
+	{
+		"overtime after a break and at night",
+		Spans{New(monday(8, 0), monday(12, 0)), New(monday(12, 30), monday(18, 0)), New(monday(22, 0), monday(23, 30))},
+		Spans{
+			&RateSegment{TimeSpan{monday(8, 0), monday(12, 0)}, []string{}},
+			&RateSegment{TimeSpan{monday(12, 30), monday(16, 30)}, []string{}},
+			&RateSegment{TimeSpan{monday(16, 30), monday(18, 0)}, []string{"overtime"}},
+			&RateSegment{TimeSpan{monday(22, 0), monday(23, 0)}, []string{"overtime"}},
+			&RateSegment{TimeSpan{monday(23, 0), monday(23, 30)}, []string{"night", "overtime"}},
+		},
+		map[string]time.Duration{"night": 30 * time.Minute, "overtime": 3 * time.Hour},
+	},
+}
+
+func TestSegmentRates(t *testing.T) {
+	for _, tt := range rateTests {
+		t.Log(tt.description)
+		result := SegmentRates(tt.worked, rateRules, berlin)
+		if !reflect.DeepEqual(result.Segments, tt.segments) {
+			t.Error("Expected ", tt.segments, "\nReceived ", result.Segments)
+		}
+		if !reflect.DeepEqual(result.Totals, tt.totals) {
+			t.Error("Expected ", tt.totals, "\nReceived ", result.Totals)
+		}
+		if result.Worked != tt.worked.Duration() {
+			t.Error("Expected worked ", tt.worked.Duration(), ", received ", result.Worked)
+		}
+	}
+}
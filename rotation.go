@@ -0,0 +1,158 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// Rotation describes an on-call rotation, where the participants take turns in the given order.
+type Rotation struct {
+	// Participants take over in this order, starting over with the first after the last one.
+	Participants []string
+	// ShiftLength is the length of every shift. Multiples of a day keep the local handoff time across DST changes.
+	ShiftLength time.Duration
+	// Handoff is the weekday of the first handoff.
+	Handoff time.Weekday
+	// HandoffTime is the local time of day of every handoff, as offset from midnight.
+	HandoffTime time.Duration
+	// Location is used for the handoff weekday and time. Nil means UTC.
+	Location *time.Location
+	// Epoch is the time the rotation starts: the first participant takes over at the first handoff after Epoch.
+	Epoch time.Time
+}
+
+func (r Rotation) location() *time.Location {
+	if r.Location == nil {
+		return time.UTC
+	}
+
+	return r.Location
+}
+
+// firstHandoff returns the first handoff at or after the epoch.
+func (r Rotation) firstHandoff() time.Time {
+	epoch := r.Epoch.In(r.location())
+	days := (int(r.Handoff) - int(epoch.Weekday()) + 7) % 7
+
+	handoff := time.Date(
+		epoch.Year(), epoch.Month(), epoch.Day()+days,
+		int(r.HandoffTime/time.Hour), int(r.HandoffTime%time.Hour/time.Minute), int(r.HandoffTime%time.Minute/time.Second),
+		0, r.location(),
+	)
+	if handoff.Before(epoch) {
+		handoff = handoff.AddDate(0, 0, 7)
+	}
+
+	return handoff
+}
+
+// step moves the handoff t by n shifts.
+func (r Rotation) step(t time.Time, n int) time.Time {
+	if r.ShiftLength%(24*time.Hour) == 0 {
+		return t.AddDate(0, 0, n*int(r.ShiftLength/(24*time.Hour)))
+	}
+
+	return t.Add(time.Duration(n) * r.ShiftLength)
+}
+
+// shiftAt returns the index and handoff of the shift running at t, which must not be before the first handoff.
+func (r Rotation) shiftAt(first, t time.Time) (int, time.Time) {
+	if r.ShiftLength%(24*time.Hour) != 0 {
+		shift := int(t.Sub(first) / r.ShiftLength)
+		return shift, r.step(first, shift)
+	}
+
+	// Count calendar days, as days of 23 or 25 hours would throw off a division of durations.
+	from, to := first.In(r.location()), t.In(r.location())
+	days := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC).Sub(
+		time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)) / (24 * time.Hour)
+
+	shift := int(days / (r.ShiftLength / (24 * time.Hour)))
+	handoff := r.step(first, shift)
+	if handoff.After(t) {
+		shift--
+		handoff = r.step(first, shift)
+	}
+
+	return shift, handoff
+}
+
+// Generate returns the shifts of every participant within window. There are no shifts before the first
+// handoff at or after Epoch. Windows without an end are not generated and return no shifts.
+func (r Rotation) Generate(window Span) KeyedSpans {
+	schedule := KeyedSpans{}
+	if len(r.Participants) == 0 || r.ShiftLength <= 0 || isForever(window.End()) {
+		return schedule
+	}
+
+	first := r.firstHandoff()
+	shift, handoff := r.shiftAt(first, getMax(window.Start(), first))
+
+	n := len(r.Participants)
+	for handoff.Before(window.End()) {
+		next := r.step(handoff, 1)
+		start, end := getMax(handoff, window.Start()), getMin(next, window.End())
+
+		if start.Before(end) {
+			participant := r.Participants[(shift%n+n)%n]
+			schedule[participant] = append(schedule[participant], New(start, end))
+		}
+
+		handoff = next
+		shift++
+	}
+
+	return schedule
+}
+
+// Schedule returns the shifts of every participant within window, replaced by the overrides,
+// see ApplyOverrides. Overrides are cut to the window.
+func (r Rotation) Schedule(window Span, overrides KeyedSpans) KeyedSpans {
+	clipped := KeyedSpans{}
+	for key, spans := range overrides {
+		for _, o := range spans {
+			start, end := getMax(o.Start(), window.Start()), getMin(o.End(), window.End())
+			if start.Before(end) {
+				clipped[key] = append(clipped[key], New(start, end))
+			}
+		}
+	}
+
+	return ApplyOverrides(r.Generate(window), clipped)
+}
+
+// ApplyOverrides replaces the scheduled participants by the key of every override span for its duration.
+// The spans of every key are ordered by start. Overrides of later keys, in ascending order,
+// replace overlapping overrides of earlier keys.
+func ApplyOverrides(schedule KeyedSpans, overrides KeyedSpans) KeyedSpans {
+	result := KeyedSpans{}
+	for key, spans := range schedule {
+		result[key] = append(Spans{}, spans...)
+	}
+
+	for _, key := range overrides.Keys() {
+		for _, o := range overrides[key] {
+			result = result.Without(o)
+			result[key] = append(result[key], o)
+		}
+	}
+
+	for _, spans := range result {
+		sort.Stable(ByStart(spans))
+	}
+
+	return result
+}
+
+// OnCall returns the keys, in ascending order, with at least one span overlapping the given span,
+// e.g. the participants on call during an incident.
+func OnCall(schedule KeyedSpans, incident Span) []string {
+	keys := []string{}
+	for _, key := range schedule.Keys() {
+		if len(schedule[key].IntersectionBetween(Spans{incident})) > 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
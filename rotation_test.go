@@ -0,0 +1,106 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var weekly = Rotation{
+	Participants: []string{"alice", "bob", "carol"},
+	ShiftLength:  7 * 24 * time.Hour,
+	Handoff:      time.Monday,
+	HandoffTime:  9 * time.Hour,
+	Location:     berlin,
+	Epoch:        time.Date(2020, 9, 27, 12, 0, 0, 0, berlin),
+}
+
+var rotationWindow = New(
+	time.Date(2020, 10, 20, 0, 0, 0, 0, berlin),
+	time.Date(2020, 11, 3, 0, 0, 0, 0, berlin),
+)
+
+func TestRotation_Generate(t *testing.T) {
+	result := weekly.Generate(rotationWindow)
+	expected := KeyedSpans{
+		"alice": Spans{New(rotationWindow.Start(), time.Date(2020, 10, 26, 9, 0, 0, 0, berlin))},
+		"bob":   Spans{New(time.Date(2020, 10, 26, 9, 0, 0, 0, berlin), time.Date(2020, 11, 2, 9, 0, 0, 0, berlin))},
+		"carol": Spans{New(time.Date(2020, 11, 2, 9, 0, 0, 0, berlin), rotationWindow.End())},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+}
+
+func TestRotation_GenerateBeforeEpoch(t *testing.T) {
+	result := weekly.Generate(New(time.Date(2020, 9, 14, 0, 0, 0, 0, berlin), time.Date(2020, 10, 6, 0, 0, 0, 0, berlin)))
+	expected := KeyedSpans{
+		"alice": Spans{New(time.Date(2020, 9, 28, 9, 0, 0, 0, berlin), time.Date(2020, 10, 5, 9, 0, 0, 0, berlin))},
+		"bob":   Spans{New(time.Date(2020, 10, 5, 9, 0, 0, 0, berlin), time.Date(2020, 10, 6, 0, 0, 0, 0, berlin))},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+
+	if result := weekly.Generate(Until(time.Date(2020, 10, 6, 0, 0, 0, 0, berlin))); !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+	if result := weekly.Generate(From(weekly.Epoch)); len(result) != 0 {
+		t.Error("Expected no shifts for a window without end, received ", result)
+	}
+}
+
+func TestRotation_GenerateDistant(t *testing.T) {
+	// 2100-03-01 is a Monday, 4144 weeks after the first handoff: 4144 % 3 == 1.
+	window := New(time.Date(2100, 3, 1, 0, 0, 0, 0, berlin), time.Date(2100, 3, 2, 0, 0, 0, 0, berlin))
+	result := weekly.Generate(window)
+	expected := KeyedSpans{
+		"alice": Spans{New(window.Start(), time.Date(2100, 3, 1, 9, 0, 0, 0, berlin))},
+		"bob":   Spans{New(time.Date(2100, 3, 1, 9, 0, 0, 0, berlin), window.End())},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+
+	twelve := weekly
+	twelve.ShiftLength = 12 * time.Hour
+	result = twelve.Generate(New(time.Date(2020, 10, 1, 0, 0, 0, 0, berlin), time.Date(2020, 10, 1, 12, 0, 0, 0, berlin)))
+	// The sixth shift of 12 hours after the first handoff on 2020-09-28 09:00 starts on 2020-10-01 09:00.
+	expected = KeyedSpans{
+		"carol": Spans{New(time.Date(2020, 10, 1, 0, 0, 0, 0, berlin), time.Date(2020, 10, 1, 9, 0, 0, 0, berlin))},
+		"alice": Spans{New(time.Date(2020, 10, 1, 9, 0, 0, 0, berlin), time.Date(2020, 10, 1, 12, 0, 0, 0, berlin))},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+}
+
+func TestRotation_Schedule(t *testing.T) {
+	overrides := KeyedSpans{
+		"carol": Spans{New(time.Date(2020, 10, 27, 12, 0, 0, 0, berlin), time.Date(2020, 10, 27, 18, 0, 0, 0, berlin))},
+		"dave":  Spans{New(time.Date(2020, 11, 2, 20, 0, 0, 0, berlin), time.Date(2020, 11, 4, 0, 0, 0, 0, berlin))},
+	}
+
+	result := weekly.Schedule(rotationWindow, overrides)
+	expected := KeyedSpans{
+		"alice": Spans{New(rotationWindow.Start(), time.Date(2020, 10, 26, 9, 0, 0, 0, berlin))},
+		"bob": Spans{
+			New(time.Date(2020, 10, 26, 9, 0, 0, 0, berlin), time.Date(2020, 10, 27, 12, 0, 0, 0, berlin)),
+			New(time.Date(2020, 10, 27, 18, 0, 0, 0, berlin), time.Date(2020, 11, 2, 9, 0, 0, 0, berlin)),
+		},
+		"carol": Spans{
+			New(time.Date(2020, 10, 27, 12, 0, 0, 0, berlin), time.Date(2020, 10, 27, 18, 0, 0, 0, berlin)),
+			New(time.Date(2020, 11, 2, 9, 0, 0, 0, berlin), time.Date(2020, 11, 2, 20, 0, 0, 0, berlin)),
+		},
+		"dave": Spans{New(time.Date(2020, 11, 2, 20, 0, 0, 0, berlin), rotationWindow.End())},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+
+	incident := New(time.Date(2020, 10, 27, 17, 0, 0, 0, berlin), time.Date(2020, 10, 27, 19, 0, 0, 0, berlin))
+	onCall := OnCall(result, incident)
+	if !reflect.DeepEqual(onCall, []string{"bob", "carol"}) {
+		t.Error("Expected [bob carol], received ", onCall)
+	}
+}
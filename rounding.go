@@ -0,0 +1,188 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// Rules reported in an Adjustment.
+const (
+	SpanRoundingRule = "span"
+	SpanMinimumRule  = "minimum"
+	DayRoundingRule  = "day"
+)
+
+// RoundingPolicy describes how tracked time is rounded for billing, e.g. every span up to
+// 15 minutes, or only the daily total to 6 minutes. Rules with a zero value are not applied.
+// The rules are applied in the order of the fields.
+type RoundingPolicy struct {
+	// SpanUnit rounds the duration of every span to a multiple of it, according to SpanMode.
+	SpanUnit time.Duration
+	SpanMode SnapMode
+	// SpanMinimum is the minimum duration charged for every span.
+	SpanMinimum time.Duration
+	// DayUnit rounds the total of every day to a multiple of it, according to DayMode.
+	DayUnit time.Duration
+	DayMode SnapMode
+	// Location determines the days. Nil means UTC.
+	Location *time.Location
+}
+
+// Adjustment explains a change made by a rule of a RoundingPolicy.
+type Adjustment struct {
+	// Rule is one of SpanRoundingRule, SpanMinimumRule and DayRoundingRule.
+	Rule string
+	// Span is the adjusted span, or the day for DayRoundingRule.
+	Span Span
+	// Amount is the time added, or removed if negative.
+	Amount time.Duration
+}
+
+// RoundedDay is the total of a day before and after rounding.
+type RoundedDay struct {
+	Day     *TimeSpan
+	Actual  time.Duration
+	Rounded time.Duration
+}
+
+// RoundingResult is returned by RoundingPolicy.Apply.
+type RoundingResult struct {
+	// Spans are the adjusted spans ordered by start. Their end is moved to match the rounded duration,
+	// so they may overlap their successors.
+	Spans Spans
+	// Days holds the totals of every day with spans, ordered by day.
+	Days []RoundedDay
+	// Actual is the duration of the given spans, Rounded the duration of the adjusted ones.
+	Actual  time.Duration
+	Rounded time.Duration
+	// Adjustments lists every change in the order it was made.
+	Adjustments []Adjustment
+}
+
+func (p RoundingPolicy) location() *time.Location {
+	if p.Location == nil {
+		return time.UTC
+	}
+
+	return p.Location
+}
+
+// roundDuration rounds d to a multiple of unit.
+func roundDuration(d, unit time.Duration, mode SnapMode) time.Duration {
+	rest := d % unit
+	d -= rest
+
+	switch {
+	case mode == Ceil && rest > 0:
+		d += unit
+	case mode == Round && rest*2 >= unit:
+		d += unit
+	}
+
+	return d
+}
+
+func (r *RoundingResult) adjust(rule string, span Span, amount time.Duration) {
+	if amount != 0 {
+		r.Adjustments = append(r.Adjustments, Adjustment{Rule: rule, Span: span, Amount: amount})
+	}
+}
+
+// ApplyWithHandler rounds the spans according to the policy. Spans are counted for the day they start on.
+// Unbounded spans are left unchanged. The handler is called for every adjusted span.
+func (p RoundingPolicy) ApplyWithHandler(s Spans, handlerFunc TransformHandlerFunc) RoundingResult {
+	sorted := append(Spans{}, s...)
+	sort.Stable(ByStart(sorted))
+
+	result := RoundingResult{Days: []RoundedDay{}, Actual: s.Duration()}
+	ends := make([]time.Time, len(sorted))
+	var days [][]int
+
+	for i, span := range sorted {
+		ends[i] = span.End()
+		if IsUnbounded(span) {
+			continue
+		}
+
+		d := span.End().Sub(span.Start())
+		if p.SpanUnit > 0 {
+			rounded := roundDuration(d, p.SpanUnit, p.SpanMode)
+			result.adjust(SpanRoundingRule, span, rounded-d)
+			d = rounded
+		}
+		if d < p.SpanMinimum {
+			result.adjust(SpanMinimumRule, span, p.SpanMinimum-d)
+			d = p.SpanMinimum
+		}
+		ends[i] = span.Start().Add(d)
+
+		last := len(days) - 1
+		if last < 0 || !p.day(sorted[days[last][0]]).Start().Equal(p.day(span).Start()) {
+			days = append(days, nil)
+			last++
+		}
+		days[last] = append(days[last], i)
+	}
+
+	for _, indices := range days {
+		day := RoundedDay{Day: p.day(sorted[indices[0]])}
+		for _, i := range indices {
+			day.Actual += sorted[i].End().Sub(sorted[i].Start())
+			day.Rounded += ends[i].Sub(sorted[i].Start())
+		}
+
+		if p.DayUnit > 0 {
+			rounded := roundDuration(day.Rounded, p.DayUnit, p.DayMode)
+			result.adjust(DayRoundingRule, day.Day, rounded-day.Rounded)
+
+			// Time is added to the last span of the day, and removed from the last spans backwards.
+			delta := rounded - day.Rounded
+			for j := len(indices) - 1; j >= 0 && delta != 0; j-- {
+				i := indices[j]
+				if delta > 0 {
+					ends[i] = ends[i].Add(delta)
+					break
+				}
+				removed := getMaxDuration(delta, sorted[i].Start().Sub(ends[i]))
+				ends[i] = ends[i].Add(removed)
+				delta -= removed
+			}
+			day.Rounded = rounded
+		}
+
+		result.Days = append(result.Days, day)
+	}
+
+	result.Spans = Spans{}
+	for i, span := range sorted {
+		if ends[i].Equal(span.End()) {
+			result.Spans = append(result.Spans, span)
+			continue
+		}
+		result.Spans = append(result.Spans, handlerFunc(span, New(span.Start(), ends[i])))
+	}
+	result.Rounded = result.Spans.Duration()
+
+	return result
+}
+
+// Apply rounds the spans according to the policy, see ApplyWithHandler.
+func (p RoundingPolicy) Apply(s Spans) RoundingResult {
+	return p.ApplyWithHandler(s, keepTransformed)
+}
+
+// day returns the day span starts on.
+func (p RoundingPolicy) day(span Span) *TimeSpan {
+	start := span.Start().In(p.location())
+	midnight := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, p.location())
+
+	return New(midnight, midnight.AddDate(0, 0, 1))
+}
+
+func getMaxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,114 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var billable = Spans{
+	New(monday(9, 0), monday(9, 7)),
+	New(monday(10, 0), monday(11, 20)),
+	New(monday(14, 0), monday(14, 44)),
+	New(monday(9, 0).AddDate(0, 0, 1), monday(9, 31).AddDate(0, 0, 1)),
+}
+
+var roundingTests = []struct {
+	description string
+	policy      RoundingPolicy
+	expected    Spans
+	rounded     time.Duration
+	adjustments []Adjustment
+}{
+	{
+		"every span up to 15 minutes",
+		RoundingPolicy{SpanUnit: 15 * time.Minute, SpanMode: Ceil},
+		Spans{
+			New(monday(9, 0), monday(9, 15)),
+			New(monday(10, 0), monday(11, 30)),
+			New(monday(14, 0), monday(14, 45)),
+			New(monday(9, 0).AddDate(0, 0, 1), monday(9, 45).AddDate(0, 0, 1)),
+		},
+		3*time.Hour + 15*time.Minute,
+		[]Adjustment{
+			{SpanRoundingRule, billable[0], 8 * time.Minute},
+			{SpanRoundingRule, billable[1], 10 * time.Minute},
+			{SpanRoundingRule, billable[2], time.Minute},
+			{SpanRoundingRule, billable[3], 14 * time.Minute},
+		},
+	},
+	{
+		"nearest quarter with a minimum of 30 minutes",
+		RoundingPolicy{SpanUnit: 15 * time.Minute, SpanMode: Round, SpanMinimum: 30 * time.Minute},
+		Spans{
+			New(monday(9, 0), monday(9, 30)),
+			New(monday(10, 0), monday(11, 15)),
+			New(monday(14, 0), monday(14, 45)),
+			New(monday(9, 0).AddDate(0, 0, 1), monday(9, 30).AddDate(0, 0, 1)),
+		},
+		3 * time.Hour,
+		[]Adjustment{
+			{SpanRoundingRule, billable[0], -7 * time.Minute},
+			{SpanMinimumRule, billable[0], 30 * time.Minute},
+			{SpanRoundingRule, billable[1], -5 * time.Minute},
+			{SpanRoundingRule, billable[2], time.Minute},
+			{SpanRoundingRule, billable[3], -time.Minute},
+		},
+	},
+	{
+		"only the daily total down to an hour",
+		RoundingPolicy{DayUnit: time.Hour, DayMode: Floor, Location: berlin},
+		Spans{
+			New(monday(9, 0), monday(9, 7)),
+			New(monday(10, 0), monday(11, 20)),
+			New(monday(14, 0), monday(14, 33)),
+			New(monday(9, 0).AddDate(0, 0, 1), monday(9, 0).AddDate(0, 0, 1)),
+		},
+		2 * time.Hour,
+		[]Adjustment{
+			{DayRoundingRule, New(monday(0, 0), monday(0, 0).AddDate(0, 0, 1)), -11 * time.Minute},
+			{DayRoundingRule, New(monday(0, 0).AddDate(0, 0, 1), monday(0, 0).AddDate(0, 0, 2)), -31 * time.Minute},
+		},
+	},
+}
+
+func TestRoundingPolicy_Apply(t *testing.T) {
+	for _, tt := range roundingTests {
+		t.Log(tt.description)
+		result := tt.policy.Apply(billable)
+		if !reflect.DeepEqual(result.Spans, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result.Spans)
+		}
+		if result.Actual != 2*time.Hour+42*time.Minute || result.Rounded != tt.rounded {
+			t.Error("Unexpected durations ", result.Actual, result.Rounded)
+		}
+		if !reflect.DeepEqual(result.Adjustments, tt.adjustments) {
+			t.Error("Expected ", tt.adjustments, "\nReceived ", result.Adjustments)
+		}
+	}
+}
+
+func TestRoundingPolicy_Days(t *testing.T) {
+	policy := RoundingPolicy{SpanUnit: 15 * time.Minute, SpanMode: Ceil, DayUnit: 6 * time.Minute, DayMode: Ceil, Location: berlin}
+	result := policy.Apply(billable)
+
+	if len(result.Days) != 2 {
+		t.Fatal("Expected two days, received ", result.Days)
+	}
+	if result.Days[0].Actual != 2*time.Hour+11*time.Minute || result.Days[0].Rounded != 2*time.Hour+30*time.Minute {
+		t.Error("Unexpected first day ", result.Days[0])
+	}
+	if result.Rounded != 3*time.Hour+18*time.Minute {
+		t.Error("Expected 3h18m, received ", result.Rounded)
+	}
+}
+
+func TestRoundingPolicy_DayRemoval(t *testing.T) {
+	spans := Spans{New(monday(9, 0), monday(10, 0)), New(monday(10, 30), monday(10, 35)), New(monday(11, 0), monday(11, 20))}
+	result := RoundingPolicy{DayUnit: time.Hour, DayMode: Floor}.Apply(spans)
+
+	expected := Spans{New(monday(9, 0), monday(10, 0)), New(monday(10, 30), monday(10, 30)), New(monday(11, 0), monday(11, 0))}
+	if !reflect.DeepEqual(result.Spans, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result.Spans)
+	}
+}
@@ -0,0 +1,86 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// WeightFunc is used by MaxWeight to determine the value of a span.
+type WeightFunc func(Span) float64
+
+// byEndIndex returns the indices of s ordered by end point, then start point.
+// Spans with identical start and end keep their order in s.
+func byEndIndex(s Spans) []int {
+	order := make([]int, len(s))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := s[order[i]], s[order[j]]
+		if a.End().Equal(b.End()) {
+			return a.Start().Before(b.Start())
+		}
+		return a.End().Before(b.End())
+	})
+
+	return order
+}
+
+// MaxWeight returns the subset of non-overlapping spans with the maximum total weight, ordered by start.
+// Spans with a weight of zero or less are never chosen. If several subsets have the same weight,
+// spans ending earlier are preferred, so the result is deterministic for a given input.
+func (s Spans) MaxWeight(weight WeightFunc) Spans {
+	order := byEndIndex(s)
+	ends := make([]time.Time, len(order))
+	for i, o := range order {
+		ends[i] = s[o].End()
+	}
+
+	// best[j] is the maximum weight achievable with the first j spans of order,
+	// compatible[j] the number of spans in order ending before the j-th one starts.
+	best := make([]float64, len(order)+1)
+	compatible := make([]int, len(order))
+	take := make([]bool, len(order))
+
+	for j, o := range order {
+		start := s[o].Start()
+		p := sort.Search(j, func(i int) bool { return ends[i].After(start) })
+		compatible[j] = p
+
+		with := weight(s[o]) + best[p]
+		if with > best[j] {
+			best[j+1] = with
+			take[j] = true
+		} else {
+			best[j+1] = best[j]
+		}
+	}
+
+	chosen := Spans{}
+	for j := len(order) - 1; j >= 0; {
+		if take[j] {
+			chosen = append(chosen, s[order[j]])
+			j = compatible[j] - 1
+			continue
+		}
+		j--
+	}
+
+	sort.Stable(ByStart(chosen))
+
+	return chosen
+}
+
+// MaxCount returns the largest subset of non-overlapping spans, ordered by start.
+// Of several subsets with the same size, the one choosing the earliest ending spans is returned.
+func (s Spans) MaxCount() Spans {
+	chosen := Spans{}
+	for _, o := range byEndIndex(s) {
+		if len(chosen) > 0 && s[o].Start().Before(chosen[len(chosen)-1].End()) {
+			continue
+		}
+		chosen = append(chosen, s[o])
+	}
+
+	return chosen
+}
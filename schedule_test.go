@@ -0,0 +1,64 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	requestA = New(time.Date(2020, 9, 28, 9, 0, 0, 0, berlin), time.Date(2020, 9, 28, 11, 0, 0, 0, berlin))
+	requestB = New(time.Date(2020, 9, 28, 10, 0, 0, 0, berlin), time.Date(2020, 9, 28, 12, 0, 0, 0, berlin))
+	requestC = New(time.Date(2020, 9, 28, 11, 0, 0, 0, berlin), time.Date(2020, 9, 28, 13, 0, 0, 0, berlin))
+	requestD = New(time.Date(2020, 9, 28, 12, 0, 0, 0, berlin), time.Date(2020, 9, 28, 14, 0, 0, 0, berlin))
+)
+
+var maxWeightTests = []struct {
+	description string
+	spans       Spans
+	weights     map[Span]float64
+	expected    Spans
+}{
+	{
+		"no spans",
+		Spans{},
+		nil,
+		Spans{},
+	},
+	{
+		"equal weight, earlier ending spans win",
+		Spans{requestD, requestC, requestB, requestA},
+		map[Span]float64{requestA: 3, requestB: 5, requestC: 3, requestD: 1},
+		Spans{requestA, requestC},
+	},
+	{
+		"heavy span wins over two lighter ones",
+		Spans{requestA, requestB, requestC, requestD},
+		map[Span]float64{requestA: 3, requestB: 10, requestC: 3, requestD: 1},
+		Spans{requestB, requestD},
+	},
+	{
+		"worthless spans are not chosen",
+		Spans{requestA, requestC},
+		map[Span]float64{requestA: 0, requestC: -1},
+		Spans{},
+	},
+}
+
+func TestSpans_MaxWeight(t *testing.T) {
+	for _, tt := range maxWeightTests {
+		t.Log(tt.description)
+		result := tt.spans.MaxWeight(func(s Span) float64 { return tt.weights[s] })
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
+
+func TestSpans_MaxCount(t *testing.T) {
+	result := Spans{requestD, requestB, requestC, requestA}.MaxCount()
+	expected := Spans{requestA, requestC}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+}
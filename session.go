@@ -0,0 +1,99 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// Sessionizer turns a stream of event timestamps into sessions of activity. Events closer than the
+// timeout to the previous event belong to the same session. It should be constructed with NewSessionizer.
+type Sessionizer struct {
+	timeout time.Duration
+	pad     time.Duration
+	first   time.Time
+	last    time.Time
+	open    bool
+}
+
+// NewSessionizer creates a Sessionizer which closes a session after timeout without events
+// and pads every session by pad before its first and after its last event.
+func NewSessionizer(timeout, pad time.Duration) *Sessionizer {
+	return &Sessionizer{
+		timeout: timeout,
+		pad:     pad,
+	}
+}
+
+// Add adds an event to the current session. If the event is too far from the previous one, the
+// current session is closed and returned, and the event starts a new session; otherwise Add returns nil.
+// Events are expected in chronological order. Late events are added to the current session, but those
+// earlier than the timeout before the latest event are ignored, so they cannot stretch it backwards.
+func (z *Sessionizer) Add(t time.Time) *TimeSpan {
+	if !z.open {
+		z.first, z.last, z.open = t, t, true
+		return nil
+	}
+
+	if t.Before(z.last) {
+		if z.last.Sub(t) < z.timeout {
+			z.first = getMin(z.first, t)
+		}
+		return nil
+	}
+
+	if t.Sub(z.last) < z.timeout {
+		z.last = t
+		return nil
+	}
+
+	closed := z.session()
+	z.first, z.last = t, t
+
+	return closed
+}
+
+// Expire closes and returns the current session if there was no event for the timeout before now,
+// or nil otherwise. It lets sessions be closed while no further events arrive.
+func (z *Sessionizer) Expire(now time.Time) *TimeSpan {
+	if !z.open || now.Sub(z.last) < z.timeout {
+		return nil
+	}
+
+	return z.Flush()
+}
+
+// Flush closes and returns the current session, or nil if there is none.
+func (z *Sessionizer) Flush() *TimeSpan {
+	if !z.open {
+		return nil
+	}
+
+	z.open = false
+
+	return z.session()
+}
+
+func (z *Sessionizer) session() *TimeSpan {
+	return New(z.first.Add(-z.pad), z.last.Add(z.pad))
+}
+
+// Sessionize groups the event timestamps into sessions, ordered by start. Consecutive events
+// closer than timeout belong to one session, which is padded by pad on both sides.
+// Padded sessions may overlap if pad is larger than half of timeout.
+func Sessionize(events []time.Time, timeout, pad time.Duration) Spans {
+	sorted := append([]time.Time{}, events...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	z := NewSessionizer(timeout, pad)
+	sessions := Spans{}
+	for _, t := range sorted {
+		if s := z.Add(t); s != nil {
+			sessions = append(sessions, s)
+		}
+	}
+	if s := z.Flush(); s != nil {
+		sessions = append(sessions, s)
+	}
+
+	return sessions
+}
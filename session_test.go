@@ -0,0 +1,134 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var sessionizeTests = []struct {
+	description string
+	events      []time.Time
+	timeout     time.Duration
+	pad         time.Duration
+	expected    Spans
+}{
+	{
+		"no events",
+		nil,
+		time.Minute,
+		0,
+		Spans{},
+	},
+	{
+		"single event",
+		[]time.Time{time.Date(2020, 9, 26, 10, 0, 0, 0, berlin)},
+		time.Minute,
+		30 * time.Second,
+		Spans{
+			New(
+				time.Date(2020, 9, 26, 9, 59, 30, 0, berlin),
+				time.Date(2020, 9, 26, 10, 0, 30, 0, berlin),
+			),
+		},
+	},
+	{
+		"unordered events, gap of exactly the timeout splits",
+		[]time.Time{
+			time.Date(2020, 9, 26, 10, 0, 0, 0, berlin),
+			time.Date(2020, 9, 26, 10, 4, 59, 0, berlin),
+			time.Date(2020, 9, 26, 10, 1, 0, 0, berlin),
+			time.Date(2020, 9, 26, 10, 9, 59, 0, berlin),
+		},
+		5 * time.Minute,
+		0,
+		Spans{
+			New(
+				time.Date(2020, 9, 26, 10, 0, 0, 0, berlin),
+				time.Date(2020, 9, 26, 10, 4, 59, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 26, 10, 9, 59, 0, berlin),
+				time.Date(2020, 9, 26, 10, 9, 59, 0, berlin),
+			),
+		},
+	},
+}
+
+func TestSessionize(t *testing.T) {
+	for _, tt := range sessionizeTests {
+		t.Log(tt.description)
+		result := Sessionize(tt.events, tt.timeout, tt.pad)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
+
+func TestSessionizer(t *testing.T) {
+	z := NewSessionizer(time.Minute, 0)
+	start := time.Date(2020, 9, 26, 10, 0, 0, 0, berlin)
+
+	if s := z.Flush(); s != nil {
+		t.Error("Expected no session before the first event, received ", s)
+	}
+	if s := z.Add(start); s != nil {
+		t.Error("Expected no closed session, received ", s)
+	}
+	if s := z.Add(start.Add(30 * time.Second)); s != nil {
+		t.Error("Expected no closed session, received ", s)
+	}
+
+	s := z.Add(start.Add(2 * time.Minute))
+	expected := New(start, start.Add(30*time.Second))
+	if !reflect.DeepEqual(s, expected) {
+		t.Error("Expected ", expected, "\nReceived ", s)
+	}
+
+	s = z.Flush()
+	expected = New(start.Add(2*time.Minute), start.Add(2*time.Minute))
+	if !reflect.DeepEqual(s, expected) {
+		t.Error("Expected ", expected, "\nReceived ", s)
+	}
+}
+
+func TestSessionizer_Expire(t *testing.T) {
+	z := NewSessionizer(time.Minute, 0)
+	start := time.Date(2020, 9, 26, 10, 0, 0, 0, berlin)
+
+	if s := z.Expire(start); s != nil {
+		t.Error("Expected no session before the first event, received ", s)
+	}
+	z.Add(start)
+	if s := z.Expire(start.Add(59 * time.Second)); s != nil {
+		t.Error("Expected the session to be open before the timeout, received ", s)
+	}
+
+	s := z.Expire(start.Add(time.Minute))
+	expected := New(start, start)
+	if !reflect.DeepEqual(s, expected) {
+		t.Error("Expected ", expected, "\nReceived ", s)
+	}
+	if s := z.Flush(); s != nil {
+		t.Error("Expected no session after it expired, received ", s)
+	}
+}
+
+func TestSessionizer_Stale(t *testing.T) {
+	z := NewSessionizer(time.Minute, 0)
+	start := time.Date(2020, 9, 26, 10, 0, 0, 0, berlin)
+
+	z.Add(start)
+	if s := z.Add(start.Add(-2 * time.Hour)); s != nil {
+		t.Error("Expected a stale event to be ignored, received ", s)
+	}
+	if s := z.Add(start.Add(-30 * time.Second)); s != nil {
+		t.Error("Expected a late event to be added, received ", s)
+	}
+
+	s := z.Flush()
+	expected := New(start.Add(-30*time.Second), start)
+	if !reflect.DeepEqual(s, expected) {
+		t.Error("Expected ", expected, "\nReceived ", s)
+	}
+}
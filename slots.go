@@ -0,0 +1,56 @@
+package spaniel
+
+import (
+	"sort"
+	"time"
+)
+
+// SlotOptions configures the search performed by FindSlots.
+type SlotOptions struct {
+	// MinDuration is the minimum length of a returned slot.
+	MinDuration time.Duration
+	// Buffer is kept free before and after every busy span.
+	Buffer time.Duration
+	// Grid aligns the start of every slot to a multiple of Grid, counted from
+	// local midnight (e.g. every 15 minutes). Zero disables the alignment.
+	Grid time.Duration
+}
+
+// FindSlots returns the free slots within workingHours that are not covered by
+// any of the busy spans, ranked by earliest start.
+// Every busy span is extended by opts.Buffer on both sides before it is removed
+// from the working hours, slot starts are aligned to opts.Grid and slots shorter
+// than opts.MinDuration are dropped. The working hours are expected not to overlap.
+func FindSlots(workingHours Spans, busy []Spans, opts SlotOptions) Spans {
+	free := Spans{}
+	for _, w := range workingHours {
+		free = append(free, New(w.Start(), w.End()))
+	}
+
+	for _, spans := range busy {
+		for _, b := range spans {
+			free = free.Without(New(
+				b.Start().Add(-opts.Buffer),
+				b.End().Add(opts.Buffer),
+			))
+		}
+	}
+
+	slots := Spans{}
+	for _, f := range free {
+		start := f.Start()
+		if opts.Grid > 0 {
+			start = snap(start, opts.Grid, Ceil, start.Location())
+		}
+
+		if !start.Before(f.End()) || f.End().Sub(start) < opts.MinDuration {
+			continue
+		}
+
+		slots = append(slots, New(start, f.End()))
+	}
+
+	sort.Stable(ByStart(slots))
+
+	return slots
+}
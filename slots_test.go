@@ -0,0 +1,100 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var workingDay = Spans{
+	New(
+		time.Date(2020, 9, 28, 9, 0, 0, 0, berlin),
+		time.Date(2020, 9, 28, 17, 0, 0, 0, berlin),
+	),
+}
+
+var findSlotsTests = []struct {
+	description string
+	working     Spans
+	busy        []Spans
+	options     SlotOptions
+	expected    Spans
+}{
+	{
+		"no appointments",
+		workingDay,
+		nil,
+		SlotOptions{},
+		workingDay,
+	},
+	{
+		"two people with overlapping appointments",
+		workingDay,
+		[]Spans{
+			{
+				New(
+					time.Date(2020, 9, 28, 10, 0, 0, 0, berlin),
+					time.Date(2020, 9, 28, 11, 0, 0, 0, berlin),
+				),
+			},
+			{
+				New(
+					time.Date(2020, 9, 28, 10, 30, 0, 0, berlin),
+					time.Date(2020, 9, 28, 12, 0, 0, 0, berlin),
+				),
+				New(
+					time.Date(2020, 9, 28, 16, 0, 0, 0, berlin),
+					time.Date(2020, 9, 28, 18, 0, 0, 0, berlin),
+				),
+			},
+		},
+		SlotOptions{},
+		Spans{
+			New(
+				time.Date(2020, 9, 28, 9, 0, 0, 0, berlin),
+				time.Date(2020, 9, 28, 10, 0, 0, 0, berlin),
+			),
+			New(
+				time.Date(2020, 9, 28, 12, 0, 0, 0, berlin),
+				time.Date(2020, 9, 28, 16, 0, 0, 0, berlin),
+			),
+		},
+	},
+	{
+		"buffer, grid and minimum duration",
+		workingDay,
+		[]Spans{
+			{
+				New(
+					time.Date(2020, 9, 28, 9, 40, 0, 0, berlin),
+					time.Date(2020, 9, 28, 10, 2, 0, 0, berlin),
+				),
+				New(
+					time.Date(2020, 9, 28, 14, 0, 0, 0, berlin),
+					time.Date(2020, 9, 28, 16, 30, 0, 0, berlin),
+				),
+			},
+		},
+		SlotOptions{
+			MinDuration: time.Hour,
+			Buffer:      10 * time.Minute,
+			Grid:        15 * time.Minute,
+		},
+		Spans{
+			New(
+				time.Date(2020, 9, 28, 10, 15, 0, 0, berlin),
+				time.Date(2020, 9, 28, 13, 50, 0, 0, berlin),
+			),
+		},
+	},
+}
+
+func TestFindSlots(t *testing.T) {
+	for _, tt := range findSlotsTests {
+		t.Log(tt.description)
+		result := FindSlots(tt.working, tt.busy, tt.options)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package spaniel
+
+import "time"
+
+// contains returns true if t lies within the [) span a.
+func contains(a Span, t time.Time) bool {
+	return !t.Before(a.Start()) && t.Before(a.End())
+}
+
+// At returns the spans containing t, in their order in s. As spans are [), a span
+// contains its start but not its end, and instants contain nothing.
+func (s Spans) At(t time.Time) Spans {
+	return filter(s, func(a Span) bool {
+		return !contains(a, t)
+	})
+}
+
+// Contains returns true if at least one span contains t, see At.
+func (s Spans) Contains(t time.Time) bool {
+	for _, a := range s {
+		if contains(a, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Covers returns true if b is completely covered by the union of the spans.
+// Unlike Within, b may be covered by several overlapping or contiguous spans.
+// An instant is covered if it is contained in a span, see Contains.
+func (s Spans) Covers(b Span) bool {
+	if IsInstant(b) {
+		return s.Contains(b.Start())
+	}
+
+	for _, a := range s.Union() {
+		if Within(a, b) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+)
+
+var stabSpans = Spans{
+	New(monday(8, 0), monday(12, 0)),
+	New(monday(10, 0), monday(11, 0)),
+	New(monday(12, 0), monday(14, 0)),
+	New(monday(16, 0), monday(16, 0)),
+}
+
+var atTests = []struct {
+	description string
+	hour        int
+	expected    Spans
+}{
+	{"before all spans", 7, Spans{}},
+	{"at a start", 10, Spans{stabSpans[0], stabSpans[1]}},
+	{"at an end", 12, Spans{stabSpans[2]}},
+	{"in a gap", 15, Spans{}},
+	{"at an instant", 16, Spans{}},
+}
+
+func TestSpans_At(t *testing.T) {
+	for _, tt := range atTests {
+		t.Log(tt.description)
+		result := stabSpans.At(monday(tt.hour, 0))
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+		if stabSpans.Contains(monday(tt.hour, 0)) != (len(tt.expected) > 0) {
+			t.Error("Expected Contains to be ", len(tt.expected) > 0)
+		}
+	}
+}
+
+var coversTests = []struct {
+	description string
+	span        Span
+	expected    bool
+}{
+	{"covered by contiguous spans", New(monday(9, 0), monday(14, 0)), true},
+	{"reaching into a gap", New(monday(13, 0), monday(15, 0)), false},
+	{"instant at a start", New(monday(8, 0), monday(8, 0)), true},
+	{"instant at the last end", New(monday(14, 0), monday(14, 0)), false},
+}
+
+func TestSpans_Covers(t *testing.T) {
+	for _, tt := range coversTests {
+		t.Log(tt.description)
+		if stabSpans.Covers(tt.span) != tt.expected {
+			t.Error("Expected ", tt.expected, ", got ", !tt.expected)
+		}
+	}
+}
@@ -18,28 +18,54 @@ func (ts TimeSpan) Start() time.Time { return ts.start }
 // End returns the end time of a span
 func (ts TimeSpan) End() time.Time { return ts.end }
 
+// Duration returns the duration of a span, or Infinite if it is unbounded
 func (ts TimeSpan) Duration() time.Duration {
-	return ts.end.Sub(ts.start)
+	return duration(ts)
 }
 
 // MarshalJSON implements json.Marshal
+// An unbounded start or end is encoded as null.
 func (ts TimeSpan) MarshalJSON() ([]byte, error) {
 	o := struct {
-		Start time.Time `json:"start"`
-		End   time.Time `json:"end"`
-	}{
-		Start: ts.start,
-		End:   ts.end,
+		Start *time.Time `json:"start"`
+		End   *time.Time `json:"end"`
+	}{}
+
+	if !isBeginning(ts.start) {
+		o.Start = &ts.start
+	}
+	if !isForever(ts.end) {
+		o.End = &ts.end
 	}
 
 	return json.Marshal(o)
 }
 
+// marshalWithFields encodes ts like MarshalJSON, followed by the fields of extra, which must encode
+// as JSON object. Types embedding TimeSpan use it so their own fields are not lost.
+func marshalWithFields(ts TimeSpan, extra interface{}) ([]byte, error) {
+	b, err := ts.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+	if len(e) <= 2 {
+		return b, nil
+	}
+
+	return append(append(b[:len(b)-1], ','), e[1:]...), nil
+}
+
 // UnmarshalJSON implements json.Unmarshal
+// An explicit null start or end is decoded as unbounded, while a missing one is left as zero time.
 func (ts *TimeSpan) UnmarshalJSON(b []byte) (err error) {
 	var i struct {
-		Start time.Time `json:"start"`
-		End   time.Time `json:"end"`
+		Start json.RawMessage `json:"start"`
+		End   json.RawMessage `json:"end"`
 	}
 
 	err = json.Unmarshal(b, &i)
@@ -47,9 +73,25 @@ func (ts *TimeSpan) UnmarshalJSON(b []byte) (err error) {
 		return err
 	}
 
-	ts.start = i.Start
-	ts.end = i.End
+	ts.start, err = unmarshalBound(i.Start, Beginning)
+	if err != nil {
+		return err
+	}
 
+	ts.end, err = unmarshalBound(i.End, Forever)
+	return
+}
+
+// unmarshalBound decodes a start or end, returning unbounded for null and zero time if it is missing.
+func unmarshalBound(raw json.RawMessage, unbounded time.Time) (t time.Time, err error) {
+	switch string(raw) {
+	case "":
+		return
+	case "null":
+		return unbounded, nil
+	}
+
+	err = json.Unmarshal(raw, &t)
 	return
 }
 
@@ -80,11 +122,15 @@ func (ts *TimeSpan) UnmarshalBinary(data []byte) error {
 }
 
 func (ts TimeSpan) String() string {
-	return fmt.Sprintf(
-		"%s - %s",
-		ts.Start().Format("2006-01-02 15:04"),
-		ts.End().Format("2006-01-02 15:04"),
-	)
+	start, end := "..", ".."
+	if !isBeginning(ts.start) {
+		start = ts.Start().Format("2006-01-02 15:04")
+	}
+	if !isForever(ts.end) {
+		end = ts.End().Format("2006-01-02 15:04")
+	}
+
+	return fmt.Sprintf("%s - %s", start, end)
 }
 
 // New creates a span with a start and end time, with the types set to [] for instants and [) for spans.
@@ -0,0 +1,313 @@
+package spaniel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ConflictPolicy decides what a Tracker does when a task is started while another one is running.
+type ConflictPolicy int
+
+const (
+	// RejectConflicts makes Start and Resume fail with a *ConflictError while another task is running.
+	RejectConflicts ConflictPolicy = iota
+	// AutoClose stops all other running tasks when a task is started or resumed.
+	AutoClose
+)
+
+var (
+	// ErrRunning is returned when starting a task which is already running.
+	ErrRunning = errors.New("task is already running")
+	// ErrNotRunning is returned when stopping or pausing a task which is not running.
+	ErrNotRunning = errors.New("task is not running")
+	// ErrNotPaused is returned when resuming a task which is not paused.
+	ErrNotPaused = errors.New("task is not paused")
+)
+
+// ConflictError is returned under RejectConflicts when a task is started while another one is running.
+type ConflictError struct {
+	Task    string
+	Running string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("cannot start %q while %q is running", e.Task, e.Running)
+}
+
+// TrackerState is the state of a Tracker as persisted by a Store.
+type TrackerState struct {
+	// Completed holds the recorded spans of every task.
+	Completed map[string][]*TimeSpan `json:"completed"`
+	// Running holds the start of the current span of every running task.
+	Running map[string]time.Time `json:"running"`
+	// Paused lists the tasks which are paused, i.e. may be resumed.
+	Paused []string `json:"paused"`
+}
+
+func (s TrackerState) clone() TrackerState {
+	c := TrackerState{
+		Completed: map[string][]*TimeSpan{},
+		Running:   map[string]time.Time{},
+		Paused:    append([]string{}, s.Paused...),
+	}
+	for task, spans := range s.Completed {
+		c.Completed[task] = append([]*TimeSpan{}, spans...)
+	}
+	for task, start := range s.Running {
+		c.Running[task] = start
+	}
+
+	return c
+}
+
+func (s TrackerState) paused(task string) int {
+	for i, p := range s.Paused {
+		if p == task {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Store persists the state of a Tracker.
+type Store interface {
+	Load() (TrackerState, error)
+	Save(TrackerState) error
+}
+
+// MemoryStore keeps the state of a Tracker in memory. Its zero value is an empty store.
+type MemoryStore struct {
+	mu    sync.Mutex
+	state TrackerState
+}
+
+// Load returns a copy of the saved state.
+func (m *MemoryStore) Load() (TrackerState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.state.clone(), nil
+}
+
+// Save keeps a copy of state.
+func (m *MemoryStore) Save(state TrackerState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state = state.clone()
+	return nil
+}
+
+// FileStore keeps the state of a Tracker in a JSON file. Saving writes a temporary file next to it
+// and renames it, so that the file is never left half written.
+type FileStore struct {
+	Path string
+}
+
+// Load reads the state from the file, returning an empty state if it does not exist.
+func (f FileStore) Load() (TrackerState, error) {
+	b, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return TrackerState{}, nil
+	}
+	if err != nil {
+		return TrackerState{}, err
+	}
+
+	var state TrackerState
+	err = json.Unmarshal(b, &state)
+	return state, err
+}
+
+// Save writes the state to the file.
+func (f FileStore) Save(state TrackerState) error {
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(f.Path), filepath.Base(f.Path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// Tracker records the spans worked on tasks through Start, Stop, Pause and Resume.
+// It is safe for concurrent use. Every change is saved to its Store before it takes effect.
+type Tracker struct {
+	mu     sync.Mutex
+	state  TrackerState
+	store  Store
+	clock  Clock
+	policy ConflictPolicy
+}
+
+// NewTracker creates a Tracker from the state in store. A nil clock means SystemClock.
+func NewTracker(store Store, clock Clock, policy ConflictPolicy) (*Tracker, error) {
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	state, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tracker{state: state.clone(), store: store, clock: clock, policy: policy}, nil
+}
+
+// update applies change to a copy of the state, saves it and only then replaces the state.
+func (t *Tracker) update(change func(state *TrackerState, now time.Time) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state := t.state.clone()
+	if err := change(&state, t.clock.Now()); err != nil {
+		return err
+	}
+	if err := t.store.Save(state); err != nil {
+		return err
+	}
+
+	t.state = state
+	return nil
+}
+
+// begin starts task at now, resolving conflicts with other running tasks according to the policy.
+func (t *Tracker) begin(state *TrackerState, task string, now time.Time) error {
+	for _, running := range sortedTasks(state.Running) {
+		if t.policy == RejectConflicts {
+			return &ConflictError{Task: task, Running: running}
+		}
+		closeRunning(state, running, now)
+	}
+
+	if i := state.paused(task); i >= 0 {
+		state.Paused = append(state.Paused[:i], state.Paused[i+1:]...)
+	}
+	state.Running[task] = now
+
+	return nil
+}
+
+// closeRunning records the current span of the running task.
+func closeRunning(state *TrackerState, task string, now time.Time) {
+	state.Completed[task] = append(state.Completed[task], New(state.Running[task], getMax(state.Running[task], now)))
+	delete(state.Running, task)
+}
+
+// Start starts a span of task. Starting a paused task resumes it.
+func (t *Tracker) Start(task string) error {
+	return t.update(func(state *TrackerState, now time.Time) error {
+		if _, ok := state.Running[task]; ok {
+			return ErrRunning
+		}
+
+		return t.begin(state, task, now)
+	})
+}
+
+// Stop ends the current span of a running task, or the pause of a paused one.
+func (t *Tracker) Stop(task string) error {
+	return t.update(func(state *TrackerState, now time.Time) error {
+		if i := state.paused(task); i >= 0 {
+			state.Paused = append(state.Paused[:i], state.Paused[i+1:]...)
+			return nil
+		}
+		if _, ok := state.Running[task]; !ok {
+			return ErrNotRunning
+		}
+
+		closeRunning(state, task, now)
+		return nil
+	})
+}
+
+// Pause ends the current span of a running task, so that it can be resumed later.
+func (t *Tracker) Pause(task string) error {
+	return t.update(func(state *TrackerState, now time.Time) error {
+		if _, ok := state.Running[task]; !ok {
+			return ErrNotRunning
+		}
+
+		closeRunning(state, task, now)
+		state.Paused = append(state.Paused, task)
+		return nil
+	})
+}
+
+// Resume starts a new span of a paused task.
+func (t *Tracker) Resume(task string) error {
+	return t.update(func(state *TrackerState, now time.Time) error {
+		if state.paused(task) < 0 {
+			return ErrNotPaused
+		}
+
+		return t.begin(state, task, now)
+	})
+}
+
+// Running returns the running tasks in alphabetical order.
+func (t *Tracker) Running() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return sortedTasks(t.state.Running)
+}
+
+// Spans returns the spans of every task, including an *OpenSpan for the current span of a running task.
+func (t *Tracker) Spans() KeyedSpans {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	k := KeyedSpans{}
+	for task, spans := range t.state.Completed {
+		for _, s := range spans {
+			k[task] = append(k[task], s)
+		}
+	}
+	for task, start := range t.state.Running {
+		k[task] = append(k[task], NewOpen(start, t.clock))
+	}
+
+	return k
+}
+
+// Totals returns the duration tracked for every task up to now.
+func (t *Tracker) Totals() map[string]time.Duration {
+	totals := map[string]time.Duration{}
+	for task, spans := range t.Spans() {
+		totals[task] = spans.Duration()
+	}
+
+	return totals
+}
+
+func sortedTasks(running map[string]time.Time) []string {
+	tasks := []string{}
+	for task := range running {
+		tasks = append(tasks, task)
+	}
+	sort.Strings(tasks)
+
+	return tasks
+}
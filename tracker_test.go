@@ -0,0 +1,168 @@
+package spaniel
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTracker(t *testing.T) {
+	clock := NewFakeClock(monday(9, 0))
+	tracker, err := NewTracker(&MemoryStore{}, clock, RejectConflicts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tracker.Start("review"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Start("review"); err != ErrRunning {
+		t.Error("Expected ErrRunning, received ", err)
+	}
+	clock.Advance(time.Hour)
+	if err, ok := tracker.Start("support").(*ConflictError); !ok || err.Running != "review" {
+		t.Error("Expected a conflict with review, received ", err)
+	}
+
+	if err := tracker.Pause("review"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Resume("support"); err != ErrNotPaused {
+		t.Error("Expected ErrNotPaused, received ", err)
+	}
+	clock.Advance(30 * time.Minute)
+	if err := tracker.Resume("review"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(15 * time.Minute)
+
+	spans := tracker.Spans()
+	expected := Spans{New(monday(9, 0), monday(10, 0)), New(monday(10, 30), monday(10, 45))}
+	if !reflect.DeepEqual(spans["review"].Resolve(clock.Now()), expected) {
+		t.Error("Expected ", expected, "\nReceived ", spans["review"])
+	}
+	if _, ok := spans["review"][1].(*OpenSpan); !ok {
+		t.Error("Expected the running span to be open")
+	}
+
+	clock.Advance(15 * time.Minute)
+	if totals := tracker.Totals(); totals["review"] != 90*time.Minute {
+		t.Error("Expected 1h30m, received ", totals)
+	}
+
+	if err := tracker.Stop("review"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Stop("review"); err != ErrNotRunning {
+		t.Error("Expected ErrNotRunning, received ", err)
+	}
+	if running := tracker.Running(); len(running) != 0 {
+		t.Error("Expected no running tasks, received ", running)
+	}
+}
+
+func TestTracker_AutoClose(t *testing.T) {
+	clock := NewFakeClock(monday(9, 0))
+	tracker, err := NewTracker(&MemoryStore{}, clock, AutoClose)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tracker.Start("review"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+	if err := tracker.Start("support"); err != nil {
+		t.Fatal(err)
+	}
+
+	if running := tracker.Running(); !reflect.DeepEqual(running, []string{"support"}) {
+		t.Error("Expected only support to run, received ", running)
+	}
+	expected := Spans{New(monday(9, 0), monday(10, 0))}
+	if review := tracker.Spans()["review"]; !reflect.DeepEqual(review, expected) {
+		t.Error("Expected ", expected, "\nReceived ", review)
+	}
+}
+
+func TestTracker_Concurrent(t *testing.T) {
+	tracker, err := NewTracker(&MemoryStore{}, NewFakeClock(monday(9, 0)), AutoClose)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for _, task := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func(task string) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				tracker.Start(task)
+				tracker.Totals()
+			}
+		}(task)
+	}
+	wg.Wait()
+
+	if running := tracker.Running(); len(running) != 1 {
+		t.Error("Expected a single running task, received ", running)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := FileStore{Path: filepath.Join(dir, "tracker.json")}
+	clock := NewFakeClock(monday(9, 0))
+	tracker, err := NewTracker(store, clock, RejectConflicts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tracker.Start("review"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+	if err := tracker.Pause("review"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Start("support"); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := NewTracker(store, clock, RejectConflicts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+	if err := restored.Resume("review"); err == nil {
+		t.Error("Expected a conflict with the restored running task")
+	}
+	if err := restored.Stop("support"); err != nil {
+		t.Fatal(err)
+	}
+	if err := restored.Resume("review"); err != nil {
+		t.Fatal(err)
+	}
+
+	totals := restored.Totals()
+	if totals["review"] != time.Hour || totals["support"] != time.Hour {
+		t.Error("Unexpected totals ", totals)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Error("Expected no temporary files to be left, found ", len(files))
+	}
+}
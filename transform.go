@@ -0,0 +1,161 @@
+package spaniel
+
+import "time"
+
+// SnapMode determines how a time is moved to a grid.
+type SnapMode int
+
+const (
+	// Floor moves a time back to the previous grid point.
+	Floor SnapMode = iota
+	// Ceil moves a time forward to the next grid point.
+	Ceil
+	// Round moves a time to the nearest grid point, forward if it lies exactly in between.
+	Round
+)
+
+// snapEpoch is the Monday from which grids of whole days are counted, so that weekly grids snap to Mondays.
+var snapEpoch = time.Date(1970, 1, 5, 0, 0, 0, 0, time.UTC)
+
+// snap moves t to a multiple of grid, counted from midnight in loc. Grids of whole days are counted in
+// calendar days from local midnight of Monday, 5 January 1970, so days of 23 or 25 hours are handled and
+// weekly grids snap to Mondays. Grids of zero or less, and longer than a day but not whole days,
+// leave t unchanged. The result is in t's location.
+func snap(t time.Time, grid time.Duration, mode SnapMode, loc *time.Location) time.Time {
+	const day = 24 * time.Hour
+	if grid <= 0 || grid > day && grid%day != 0 {
+		return t
+	}
+
+	local := t.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	if grid >= day {
+		days := int(grid / day)
+		n := int(time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC).Sub(snapEpoch) / day)
+		floor := midnight.AddDate(0, 0, -((n%days + days) % days))
+		next := floor.AddDate(0, 0, days)
+		switch {
+		case local.Equal(floor):
+		case mode == Ceil:
+			floor = next
+		case mode == Round && local.Sub(floor) >= next.Sub(local):
+			floor = next
+		}
+		return floor.In(t.Location())
+	}
+
+	offset := local.Sub(midnight)
+	rest := offset % grid
+	offset -= rest
+
+	switch {
+	case mode == Ceil && rest > 0:
+		offset += grid
+	case mode == Round && rest*2 >= grid:
+		offset += grid
+	}
+
+	return midnight.Add(offset).In(t.Location())
+}
+
+// transform calls handlerFunc for every span with the start and end returned by f,
+// dropping the spans for which f returns false.
+func transform(s Spans, f func(Span) (time.Time, time.Time, bool), handlerFunc TransformHandlerFunc) Spans {
+	transformed := Spans{}
+	for _, span := range s {
+		start, end, ok := f(span)
+		if !ok {
+			continue
+		}
+		transformed = append(transformed, handlerFunc(span, New(start, end)))
+	}
+
+	return transformed
+}
+
+// ShiftWithHandler moves every span by d.
+func (s Spans) ShiftWithHandler(d time.Duration, handlerFunc TransformHandlerFunc) Spans {
+	return transform(s, func(span Span) (time.Time, time.Time, bool) {
+		return span.Start().Add(d), span.End().Add(d), true
+	}, handlerFunc)
+}
+
+// Shift moves every span by d.
+func (s Spans) Shift(d time.Duration) Spans {
+	return s.ShiftWithHandler(d, keepTransformed)
+}
+
+// ScaleWithHandler scales the distance of every start and end to anchor by factor.
+// A negative factor mirrors the spans around anchor. Unbounded starts and ends stay unbounded.
+func (s Spans) ScaleWithHandler(factor float64, anchor time.Time, handlerFunc TransformHandlerFunc) Spans {
+	scale := func(t time.Time) time.Time {
+		switch {
+		case isBeginning(t) && factor < 0, isForever(t) && factor >= 0:
+			return Forever
+		case isBeginning(t), isForever(t):
+			return Beginning
+		}
+		return anchor.Add(time.Duration(float64(t.Sub(anchor)) * factor))
+	}
+
+	return transform(s, func(span Span) (time.Time, time.Time, bool) {
+		start, end := scale(span.Start()), scale(span.End())
+		if factor < 0 {
+			start, end = end, start
+		}
+		return start, end, true
+	}, handlerFunc)
+}
+
+// Scale scales the distance of every start and end to anchor by factor.
+func (s Spans) Scale(factor float64, anchor time.Time) Spans {
+	return s.ScaleWithHandler(factor, anchor, keepTransformed)
+}
+
+// PadWithHandler moves the start of every span back by before and its end forward by after.
+// Negative durations shrink the spans; spans which would end before they start are dropped.
+func (s Spans) PadWithHandler(before, after time.Duration, handlerFunc TransformHandlerFunc) Spans {
+	return transform(s, func(span Span) (time.Time, time.Time, bool) {
+		start, end := span.Start().Add(-before), span.End().Add(after)
+		return start, end, !end.Before(start)
+	}, handlerFunc)
+}
+
+// Pad moves the start of every span back by before and its end forward by after.
+func (s Spans) Pad(before, after time.Duration) Spans {
+	return s.PadWithHandler(before, after, keepTransformed)
+}
+
+// ClampWithHandler cuts every span to bounds, dropping the spans which do not overlap bounds.
+// Instants are kept if bounds contain them, see At.
+func (s Spans) ClampWithHandler(bounds Span, handlerFunc TransformHandlerFunc) Spans {
+	return transform(s, func(span Span) (time.Time, time.Time, bool) {
+		if IsInstant(span) {
+			return span.Start(), span.End(), contains(bounds, span.Start())
+		}
+		return getMax(span.Start(), bounds.Start()), getMin(span.End(), bounds.End()), overlap(span, bounds)
+	}, handlerFunc)
+}
+
+// Clamp cuts every span to bounds, dropping the spans which do not overlap bounds.
+func (s Spans) Clamp(bounds Span) Spans {
+	return s.ClampWithHandler(bounds, keepTransformed)
+}
+
+// SnapWithHandler moves the start and end of every span to a multiple of grid, counted from midnight in loc,
+// e.g. to 15 minute billing units. Grids of whole days snap to local midnight, and weekly grids to Mondays.
+// Grids of zero or less, and longer than a day but not whole days, leave the spans unchanged.
+// If snapping moves the end before the start, the span collapses to an instant at its snapped start.
+func (s Spans) SnapWithHandler(grid time.Duration, startMode, endMode SnapMode, loc *time.Location, handlerFunc TransformHandlerFunc) Spans {
+	return transform(s, func(span Span) (time.Time, time.Time, bool) {
+		start := snap(span.Start(), grid, startMode, loc)
+		end := getMax(start, snap(span.End(), grid, endMode, loc))
+		return start, end, true
+	}, handlerFunc)
+}
+
+// Snap moves the start and end of every span to a multiple of grid, counted from midnight in loc.
+func (s Spans) Snap(grid time.Duration, startMode, endMode SnapMode, loc *time.Location) Spans {
+	return s.SnapWithHandler(grid, startMode, endMode, loc, keepTransformed)
+}
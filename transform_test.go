@@ -0,0 +1,132 @@
+package spaniel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var transformTests = []struct {
+	description string
+	transform   func(Spans) Spans
+	spans       Spans
+	expected    Spans
+}{
+	{
+		"shift",
+		func(s Spans) Spans { return s.Shift(-time.Hour) },
+		Spans{New(monday(9, 0), monday(10, 0))},
+		Spans{New(monday(8, 0), monday(9, 0))},
+	},
+	{
+		"scale around an anchor",
+		func(s Spans) Spans { return s.Scale(2, monday(8, 0)) },
+		Spans{New(monday(9, 0), monday(10, 0))},
+		Spans{New(monday(10, 0), monday(12, 0))},
+	},
+	{
+		"mirror around an anchor",
+		func(s Spans) Spans { return s.Scale(-1, monday(8, 0)) },
+		Spans{New(monday(9, 0), monday(10, 0))},
+		Spans{New(monday(6, 0), monday(7, 0))},
+	},
+	{
+		"pad start and end independently",
+		func(s Spans) Spans { return s.Pad(15*time.Minute, -30*time.Minute) },
+		Spans{New(monday(9, 0), monday(10, 0)), New(monday(11, 0), monday(11, 10))},
+		Spans{New(monday(8, 45), monday(9, 30))},
+	},
+	{
+		"clamp to bounds",
+		func(s Spans) Spans { return s.Clamp(New(monday(9, 30), monday(12, 0))) },
+		Spans{
+			New(monday(9, 0), monday(10, 0)),
+			New(monday(12, 0), monday(13, 0)),
+			New(monday(11, 0), monday(11, 0)),
+			New(monday(12, 0), monday(12, 0)),
+		},
+		Spans{New(monday(9, 30), monday(10, 0)), New(monday(11, 0), monday(11, 0))},
+	},
+	{
+		"snap to 15 minute billing units",
+		func(s Spans) Spans { return s.Snap(15*time.Minute, Floor, Ceil, berlin) },
+		Spans{New(monday(9, 7), monday(9, 31))},
+		Spans{New(monday(9, 0), monday(9, 45))},
+	},
+	{
+		"round to 15 minutes",
+		func(s Spans) Spans { return s.Snap(15*time.Minute, Round, Round, berlin) },
+		Spans{New(monday(9, 7), monday(9, 8))},
+		Spans{New(monday(9, 0), monday(9, 15))},
+	},
+	{
+		"snap to days across the end of daylight saving time",
+		func(s Spans) Spans { return s.Snap(24*time.Hour, Floor, Ceil, berlin) },
+		Spans{New(
+			time.Date(2020, 10, 25, 13, 0, 0, 0, berlin),
+			time.Date(2020, 10, 25, 23, 30, 0, 0, berlin),
+		)},
+		Spans{New(
+			time.Date(2020, 10, 25, 0, 0, 0, 0, berlin),
+			time.Date(2020, 10, 26, 0, 0, 0, 0, berlin),
+		)},
+	},
+	{
+		"snap to days in another location",
+		func(s Spans) Spans { return s.Snap(24*time.Hour, Floor, Floor, berlin) },
+		Spans{New(
+			time.Date(2020, 10, 26, 23, 30, 0, 0, time.UTC),
+			time.Date(2020, 10, 27, 1, 0, 0, 0, time.UTC),
+		)},
+		Spans{New(
+			time.Date(2020, 10, 26, 23, 0, 0, 0, time.UTC),
+			time.Date(2020, 10, 26, 23, 0, 0, 0, time.UTC),
+		)},
+	},
+	{
+		"snap to weeks starting on Monday",
+		func(s Spans) Spans { return s.Snap(7*24*time.Hour, Floor, Ceil, berlin) },
+		Spans{New(monday(9, 0).AddDate(0, 0, 2), monday(9, 0).AddDate(0, 0, 9))},
+		Spans{New(monday(0, 0), monday(0, 0).AddDate(0, 0, 14))},
+	},
+	{
+		"zero grid leaves spans unchanged",
+		func(s Spans) Spans { return s.Snap(0, Floor, Ceil, berlin) },
+		Spans{New(monday(9, 7), monday(9, 8))},
+		Spans{New(monday(9, 7), monday(9, 8))},
+	},
+	{
+		"negative grid leaves spans unchanged",
+		func(s Spans) Spans { return s.Snap(-time.Hour, Floor, Ceil, berlin) },
+		Spans{New(monday(9, 7), monday(9, 8))},
+		Spans{New(monday(9, 7), monday(9, 8))},
+	},
+	{
+		"grid of partial days leaves spans unchanged",
+		func(s Spans) Spans { return s.Snap(36*time.Hour, Floor, Ceil, berlin) },
+		Spans{New(monday(9, 7), monday(9, 8))},
+		Spans{New(monday(9, 7), monday(9, 8))},
+	},
+}
+
+func TestSpans_Transform(t *testing.T) {
+	for _, tt := range transformTests {
+		t.Log(tt.description)
+		result := tt.transform(tt.spans)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Error("Expected ", tt.expected, "\nReceived ", result)
+		}
+	}
+}
+
+func TestSpans_ShiftWithHandler(t *testing.T) {
+	source := roomSpan{New(monday(9, 0), monday(10, 0)), "A"}
+	result := Spans{source}.ShiftWithHandler(time.Hour, func(original, transformed Span) Span {
+		return roomSpan{transformed.(*TimeSpan), original.(roomSpan).room}
+	})
+
+	expected := Spans{roomSpan{New(monday(10, 0), monday(11, 0)), "A"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+}
@@ -0,0 +1,63 @@
+package spaniel
+
+import (
+	"math"
+	"time"
+)
+
+var (
+	// Beginning is the start of spans unbounded in the past. It is distinct from the zero time.Time.
+	Beginning = time.Unix(-1<<62, 0).UTC()
+	// Forever is the end of spans unbounded in the future.
+	Forever = time.Unix(1<<62, 0).UTC()
+
+	// Times beyond these limits are considered unbounded, so that shifting or padding an
+	// unbounded span by any time.Duration keeps it unbounded.
+	beginningLimit = time.Unix(-1<<61, 0)
+	foreverLimit   = time.Unix(1<<61, 0)
+)
+
+// Infinite is the duration reported for unbounded spans.
+const Infinite time.Duration = math.MaxInt64
+
+// maxDuration is the longest duration reported for bounded spans. Durations of more than about 292 years,
+// e.g. from the zero time.Time, saturate to it, so they are never mistaken for Infinite.
+const maxDuration = Infinite - 1
+
+// From creates a span starting at start without an end, e.g. an ongoing contract.
+func From(start time.Time) *TimeSpan {
+	return New(start, Forever)
+}
+
+// Until creates a span ending at end without a start, e.g. a policy applying "since forever".
+func Until(end time.Time) *TimeSpan {
+	return New(Beginning, end)
+}
+
+func isBeginning(t time.Time) bool {
+	return t.Before(beginningLimit)
+}
+
+func isForever(t time.Time) bool {
+	return t.After(foreverLimit)
+}
+
+// IsUnbounded returns true if the span has no start or no end.
+func IsUnbounded(a Span) bool {
+	return isBeginning(a.Start()) || isForever(a.End())
+}
+
+// duration returns the duration of a span, or Infinite for unbounded spans.
+// Durations of bounded spans saturate at maxDuration.
+func duration(a Span) time.Duration {
+	if IsUnbounded(a) {
+		return Infinite
+	}
+
+	d := a.End().Sub(a.Start())
+	if d > maxDuration {
+		return maxDuration
+	}
+
+	return d
+}
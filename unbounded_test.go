@@ -0,0 +1,139 @@
+package spaniel
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var contract = From(monday(9, 0))
+
+func TestUnbounded_Within(t *testing.T) {
+	if !Within(contract, New(monday(10, 0), monday(11, 0))) {
+		t.Error("Expected a bounded span within an open-ended one")
+	}
+	if !Within(New(Beginning, Forever), contract) {
+		t.Error("Expected the open-ended span within an unbounded one")
+	}
+	if Within(New(monday(10, 0), monday(11, 0)), contract) {
+		t.Error("Expected the open-ended span not within a bounded one")
+	}
+}
+
+func TestUnbounded_Without(t *testing.T) {
+	result := Without(contract, New(monday(10, 0), monday(11, 0)))
+	expected := Spans{New(monday(9, 0), monday(10, 0)), New(monday(11, 0), Forever)}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+
+	result = Without(New(monday(10, 0), monday(11, 0)), Until(monday(10, 30)))
+	expected = Spans{New(monday(10, 30), monday(11, 0))}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+}
+
+func TestUnbounded_Intersection(t *testing.T) {
+	result := Spans{contract, Until(monday(12, 0))}.Intersection()
+	expected := Spans{New(monday(9, 0), monday(12, 0))}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected ", expected, "\nReceived ", result)
+	}
+	if IsUnbounded(result[0]) {
+		t.Error("Expected a bounded intersection")
+	}
+}
+
+func TestUnbounded_Duration(t *testing.T) {
+	for _, s := range []*TimeSpan{contract, Until(monday(9, 0)), New(Beginning, Forever)} {
+		if d := s.Duration(); d != Infinite {
+			t.Error("Expected Infinite for ", s, ", received ", d)
+		}
+	}
+
+	spans := Spans{New(monday(8, 0), monday(9, 0)), contract}
+	if d := spans.Duration(); d != Infinite {
+		t.Error("Expected Infinite, received ", d)
+	}
+	if d := spans.CoveredDuration(); d != Infinite {
+		t.Error("Expected Infinite, received ", d)
+	}
+	if d := spans[:1].Duration(); d != time.Hour {
+		t.Error("Expected 1h, received ", d)
+	}
+
+	shifted := Spans{contract}.Shift(-24 * time.Hour)
+	if !IsUnbounded(shifted[0]) {
+		t.Error("Expected a shifted open-ended span to stay unbounded")
+	}
+
+	scaled := Spans{contract}.Scale(2, monday(8, 0))
+	if expected := (Spans{From(monday(10, 0))}); !reflect.DeepEqual(scaled, expected) {
+		t.Error("Expected ", expected, "\nReceived ", scaled)
+	}
+	mirrored := Spans{contract}.Scale(-1, monday(8, 0))
+	if expected := (Spans{Until(monday(7, 0))}); !reflect.DeepEqual(mirrored, expected) {
+		t.Error("Expected ", expected, "\nReceived ", mirrored)
+	}
+}
+
+func TestUnbounded_JSON(t *testing.T) {
+	b, err := json.Marshal(contract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"start":"2020-09-28T09:00:00+02:00","end":null}` {
+		t.Error("Unexpected ", string(b))
+	}
+
+	var decoded TimeSpan
+	if err := json.Unmarshal([]byte(`{"start":null,"end":"2020-09-28T09:00:00+02:00"}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Start().Equal(Beginning) || !decoded.End().Equal(monday(9, 0)) {
+		t.Error("Unexpected ", decoded)
+	}
+}
+
+func TestUnbounded_ISO(t *testing.T) {
+	result := FormatISO(contract)
+	if result != "2020-09-28T09:00:00+02:00/.." {
+		t.Error("Unexpected ", result)
+	}
+
+	parsed, err := ParseISO("../2020-09-28T09:00", berlin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Start().Equal(Beginning) || !parsed.End().Equal(monday(9, 0)) {
+		t.Error("Unexpected ", parsed)
+	}
+	if parsed.String() != ".. - 2020-09-28 09:00" {
+		t.Error("Unexpected ", parsed.String())
+	}
+}
+
+func TestUnbounded_JSONMissing(t *testing.T) {
+	var decoded TimeSpan
+	if err := json.Unmarshal([]byte(`{"end":"2020-09-28T09:00:00+02:00"}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Start().IsZero() || IsUnbounded(decoded) {
+		t.Error("Expected a missing start to stay zero, received ", decoded.Start())
+	}
+}
+
+func TestUnbounded_SaturatedDuration(t *testing.T) {
+	zero := Spans{New(time.Time{}, monday(9, 0))}
+	if IsUnbounded(zero[0]) {
+		t.Error("Expected a span from the zero time to be bounded")
+	}
+	if d := zero.Duration(); d == Infinite || d != maxDuration {
+		t.Error("Expected a saturated bounded duration, received ", d)
+	}
+	if d := append(zero, zero[0]).Duration(); d != maxDuration {
+		t.Error("Expected a saturated sum, received ", d)
+	}
+}